@@ -0,0 +1,59 @@
+package vswhere
+
+import (
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// Advisory is a security bulletin fixed in a given release of a Visual Studio
+// product line.
+type Advisory struct {
+	// ID identifies the advisory, such as a CVE number.
+	ID string
+
+	// MinimumVersion is the first safe installation version of the product
+	// line (e.g., "17.8.34525.116"). The product line is given by its major
+	// version, so the advisory applies to installations with the same major
+	// version that are older than MinimumVersion.
+	MinimumVersion string
+
+	// Products limits the advisory to the given product IDs. Empty applies
+	// to every product.
+	Products []string
+}
+
+// Vulnerability is an installation affected by an advisory.
+type Vulnerability struct {
+	Installation Installation
+	Advisory     Advisory
+}
+
+// CheckAdvisories returns the installations affected by each advisory,
+// ordered by installation and then by advisory. Advisories are supplied by
+// the caller, such as from their own security feed.
+func CheckAdvisories(installs []Installation, advisories []Advisory) []Vulnerability {
+	var res []Vulnerability
+	for _, install := range installs {
+		for _, a := range advisories {
+			if affects(a, install) {
+				res = append(res, Vulnerability{Installation: install, Advisory: a})
+			}
+		}
+	}
+	return res
+}
+
+func affects(a Advisory, install Installation) bool {
+	if len(a.Products) > 0 && !containsFold(a.Products, install.ProductID) {
+		return false
+	}
+	if majorVersion(install.InstallationVersion) != majorVersion(a.MinimumVersion) {
+		return false
+	}
+	return version.Compare(install.InstallationVersion, a.MinimumVersion) < 0
+}
+
+func majorVersion(v string) string {
+	return strings.SplitN(v, ".", 2)[0]
+}
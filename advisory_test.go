@@ -0,0 +1,30 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAdvisories(t *testing.T) {
+	installs := []Installation{
+		{InstanceID: "vs2019", InstallationVersion: "16.11.34114.132", ProductID: "Microsoft.VisualStudio.Product.Community"},
+		{InstanceID: "vs2022", InstallationVersion: "17.8.34322.80", ProductID: "Microsoft.VisualStudio.Product.Community"},
+		{InstanceID: "bt2022", InstallationVersion: "17.9.34607.119", ProductID: "Microsoft.VisualStudio.Product.BuildTools"},
+	}
+	advisories := []Advisory{
+		{ID: "CVE-2024-0001", MinimumVersion: "17.8.34525.116"},
+		{ID: "CVE-2024-0002", MinimumVersion: "16.11.34601.136"},
+		{ID: "CVE-2024-0003", MinimumVersion: "17.10", Products: []string{"Microsoft.VisualStudio.Product.Community"}},
+	}
+
+	var got []string
+	for _, v := range CheckAdvisories(installs, advisories) {
+		got = append(got, v.Installation.InstanceID+" "+v.Advisory.ID)
+	}
+	require.Equal(t, []string{
+		"vs2019 CVE-2024-0002",
+		"vs2022 CVE-2024-0001",
+		"vs2022 CVE-2024-0003",
+	}, got)
+}
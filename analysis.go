@@ -0,0 +1,34 @@
+package vswhere
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CodeAnalysisTools are the C++ Code Analysis (/analyze) tools of a toolset.
+type CodeAnalysisTools struct {
+	// EspXEngine is the path to the analysis engine plugin, EspXEngine.dll.
+	EspXEngine string
+
+	// RuleSetsPath is the directory holding the bundled .ruleset files. Empty
+	// if the installation doesn't include them.
+	RuleSetsPath string
+}
+
+// CodeAnalysis returns the C++ Code Analysis tools of the toolset for a host
+// and target architecture. Returns an error if the toolset doesn't support
+// /analyze for that combination.
+func (t Toolset) CodeAnalysis(host, target Arch) (CodeAnalysisTools, error) {
+	engine := filepath.Join(t.BinPath(host, target), "EspXEngine.dll")
+	if _, err := os.Stat(engine); err != nil {
+		return CodeAnalysisTools{}, fmt.Errorf("code analysis not available for toolset %s: %w", t.Version, err)
+	}
+
+	tools := CodeAnalysisTools{EspXEngine: engine}
+	ruleSets := filepath.Join(t.InstallationPath, "Team Tools", "Static Analysis Tools", "Rule Sets")
+	if fi, err := os.Stat(ruleSets); err == nil && fi.IsDir() {
+		tools.RuleSetsPath = ruleSets
+	}
+	return tools, nil
+}
@@ -0,0 +1,26 @@
+package vswhere
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeAnalysis(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "EspXEngine.dll")
+	mkfile(t, root, "Team Tools", "Static Analysis Tools", "Rule Sets", "NativeRecommendedRules.ruleset")
+
+	toolsets, err := Toolsets(Installation{InstallationPath: root})
+	require.NoError(t, err)
+	require.Len(t, toolsets, 1)
+
+	tools, err := toolsets[0].CodeAnalysis(X64, X64)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(toolsets[0].BinPath(X64, X64), "EspXEngine.dll"), tools.EspXEngine)
+	require.Equal(t, filepath.Join(root, "Team Tools", "Static Analysis Tools", "Rule Sets"), tools.RuleSetsPath)
+
+	_, err = toolsets[0].CodeAnalysis(X64, ARM64)
+	require.Error(t, err)
+}
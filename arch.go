@@ -0,0 +1,34 @@
+package vswhere
+
+import "fmt"
+
+// Arch is an MSVC target or host architecture. Its value matches the
+// directory names used by the MSVC toolset and the Windows SDK (e.g., the
+// "x64" in "bin\Hostx64\x64").
+type Arch string
+
+// Supported architectures.
+const (
+	X86   Arch = "x86"
+	X64   Arch = "x64"
+	ARM   Arch = "arm"
+	ARM64 Arch = "arm64"
+)
+
+// TargetForGOARCH returns the MSVC target architecture for a GOARCH value
+// (e.g., "amd64" maps to X64). Returns an error if goarch has no MSVC
+// equivalent.
+func TargetForGOARCH(goarch string) (Arch, error) {
+	switch goarch {
+	case "386":
+		return X86, nil
+	case "amd64":
+		return X64, nil
+	case "arm":
+		return ARM, nil
+	case "arm64":
+		return ARM64, nil
+	default:
+		return "", fmt.Errorf("no MSVC target for GOARCH %q", goarch)
+	}
+}
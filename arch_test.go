@@ -0,0 +1,24 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetForGOARCH(t *testing.T) {
+	tt := map[string]Arch{
+		"386":   X86,
+		"amd64": X64,
+		"arm":   ARM,
+		"arm64": ARM64,
+	}
+	for goarch, expect := range tt {
+		arch, err := TargetForGOARCH(goarch)
+		require.NoError(t, err)
+		require.Equal(t, expect, arch)
+	}
+
+	_, err := TargetForGOARCH("wasm")
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rule declares an installation that must be present on a machine, such as a
+// build agent image.
+type Rule struct {
+	// Name identifies the rule in results.
+	Name string
+
+	// Requirements describe the products, versions, and components of the
+	// installation.
+	Requirements Requirements
+
+	// WindowsSDKs are the Windows SDK versions (e.g., "10.0.22621.0") which
+	// must be installed with the installation.
+	WindowsSDKs []string
+}
+
+// AuditResult is the outcome of a single Rule.
+type AuditResult struct {
+	Rule Rule
+
+	// Passed reports whether an installation met the rule.
+	Passed bool
+
+	// Installation is the newest installation meeting the rule, if any.
+	Installation Installation
+
+	// Explanation describes why each installation did or didn't meet the
+	// rule.
+	Explanation Explanation
+}
+
+// String returns a human-readable summary of the result followed by its
+// explanation.
+func (r AuditResult) String() string {
+	status := "FAIL"
+	if r.Passed {
+		status = "PASS"
+	}
+	return fmt.Sprintf("%s %s\n%s", status, r.Rule.Name, r.Explanation)
+}
+
+// Audit checks each rule against the installations on the machine, returning
+// one result per rule in the same order. A rule that fails isn't an error;
+// an error is only returned if the installations couldn't be queried. Options
+// can be provided to customize how vswhere is run (e.g., WithExecutable).
+func Audit(ctx context.Context, rules []Rule, options ...Option) ([]AuditResult, error) {
+	return Default().Audit(ctx, rules, options...)
+}
+
+// Audit checks each rule against the installations found by the Finder.
+func (f *Finder) Audit(ctx context.Context, rules []Rule, options ...Option) ([]AuditResult, error) {
+	rules, err := resolveRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, r := range rules {
+		for _, id := range r.Requirements.Components {
+			if !containsFold(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	searchOpts := append(options, WithAll(true), WithPrerelease(true), WithProducts([]string{"*"}), WithIncludePackages(true))
+	installs, err := f.Find(ctx, searchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return audit(installs, componentsByInstance(installs, ids), rules), nil
+}
+
+// resolveRules validates rules and returns a copy of them with their Windows
+// SDKs added to the required components.
+func resolveRules(rules []Rule) ([]Rule, error) {
+	res := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.Requirements.Version != "" {
+			if _, err := inVersionRange("0", r.Requirements.Version); err != nil {
+				return nil, fmt.Errorf("rule %s: %w", r.Name, err)
+			}
+		}
+		components := append([]string(nil), r.Requirements.Components...)
+		for _, v := range r.WindowsSDKs {
+			id, err := WindowsSDKComponent(v)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %w", r.Name, err)
+			}
+			components = append(components, id)
+		}
+		r.Requirements.Components = components
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// audit evaluates rules, which must have been resolved with resolveRules.
+func audit(installs []Installation, components map[string][]string, rules []Rule) []AuditResult {
+	results := make([]AuditResult, 0, len(rules))
+	for _, r := range rules {
+		// explain only fails when no installation meets the rule.
+		install, ex, err := explain(installs, components, r.Requirements)
+		results = append(results, AuditResult{
+			Rule:         r,
+			Passed:       err == nil,
+			Installation: install,
+			Explanation:  ex,
+		})
+	}
+	return results
+}
@@ -0,0 +1,47 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	installs := []Installation{
+		{InstanceID: "bt2022", InstallationVersion: "17.8.34322.80", ProductID: "Microsoft.VisualStudio.Product.BuildTools", IsComplete: true, IsLaunchable: true},
+		{InstanceID: "vs2019", InstallationVersion: "16.11.34114.132", ProductID: "Microsoft.VisualStudio.Product.Community", IsComplete: true, IsLaunchable: true},
+	}
+	vc := "Microsoft.VisualStudio.Component.VC.Tools.x86.x64"
+	components := map[string][]string{
+		"bt2022": {vc, "Microsoft.VisualStudio.Component.Windows11SDK.22621"},
+		"vs2019": {vc},
+	}
+
+	rules, err := resolveRules([]Rule{
+		{
+			Name:         "build tools 2022",
+			Requirements: Requirements{Version: "[17.0,18.0)", Products: []string{"Microsoft.VisualStudio.Product.BuildTools"}, Components: []string{vc}},
+			WindowsSDKs:  []string{"10.0.22621.0"},
+		},
+		{
+			Name:         "vs2019 with sdk",
+			Requirements: Requirements{Version: "[16.0,17.0)"},
+			WindowsSDKs:  []string{"10.0.22621.0"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{vc, "Microsoft.VisualStudio.Component.Windows11SDK.22621"}, rules[0].Requirements.Components)
+
+	results := audit(installs, components, rules)
+	require.Len(t, results, 2)
+	require.True(t, results[0].Passed)
+	require.Equal(t, "bt2022", results[0].Installation.InstanceID)
+	require.False(t, results[1].Passed)
+	require.Contains(t, results[1].String(), "FAIL vs2019 with sdk")
+	require.Contains(t, results[1].String(), "rejected vs2019 (16.11.34114.132): missing components Microsoft.VisualStudio.Component.Windows11SDK.22621")
+
+	_, err = resolveRules([]Rule{{Name: "bad", Requirements: Requirements{Version: "[16.0"}}})
+	require.Error(t, err)
+	_, err = resolveRules([]Rule{{Name: "bad", WindowsSDKs: []string{"8.1"}}})
+	require.Error(t, err)
+}
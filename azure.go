@@ -0,0 +1,71 @@
+package vswhere
+
+import "context"
+
+// AzureWorkload is the workload ID of Azure development.
+const AzureWorkload = "Microsoft.VisualStudio.Workload.Azure"
+
+// Component IDs of notable tools in the Azure development workload.
+const (
+	AzureAuthoringToolsComponent     = "Microsoft.VisualStudio.Component.Azure.AuthoringTools"
+	AzureClientLibsComponent         = "Microsoft.VisualStudio.Component.Azure.ClientLibs"
+	AzureComputeEmulatorComponent    = "Microsoft.VisualStudio.Component.Azure.Compute.Emulator"
+	AzureStorageEmulatorComponent    = "Microsoft.VisualStudio.Component.Azure.Storage.Emulator"
+	AzureServiceFabricToolsComponent = "Microsoft.VisualStudio.Component.Azure.ServiceFabric.Tools"
+)
+
+var azureComponents = []string{
+	AzureAuthoringToolsComponent,
+	AzureClientLibsComponent,
+	AzureComputeEmulatorComponent,
+	AzureStorageEmulatorComponent,
+	AzureServiceFabricToolsComponent,
+}
+
+// AzureSupport is an installation with the Azure development workload.
+type AzureSupport struct {
+	Installation Installation
+
+	// Components are the IDs of the notable Azure components (see the
+	// Azure*Component constants) installed in the installation.
+	Components []string
+}
+
+// HasComponent returns true if the component with the given ID is installed.
+func (a AzureSupport) HasComponent(id string) bool {
+	for _, c := range a.Components {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAzure finds installations with the Azure development workload and
+// reports which of its notable components they include, reading the
+// packages of each installation in a single query. Options can be provided
+// to further customize the search.
+func FindAzure(ctx context.Context, options ...Option) ([]AzureSupport, error) {
+	return Default().FindAzure(ctx, options...)
+}
+
+// FindAzure finds installations with the Azure development workload using
+// the Finder.
+func (f *Finder) FindAzure(ctx context.Context, options ...Option) ([]AzureSupport, error) {
+	installs, err := f.Find(ctx, append(options, WithIncludePackages(true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []AzureSupport
+	for _, install := range installs {
+		if len(installedPackages(install, []string{AzureWorkload})) == 0 {
+			continue
+		}
+		res = append(res, AzureSupport{
+			Installation: install,
+			Components:   installedPackages(install, azureComponents),
+		})
+	}
+	return res, nil
+}
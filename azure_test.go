@@ -0,0 +1,33 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAzure(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {
+    "instanceId": "a",
+    "packages": [
+      {"id": "Microsoft.VisualStudio.Workload.Azure", "type": "Workload"},
+      {"id": "microsoft.visualstudio.component.azure.clientlibs", "type": "Component"}
+    ]
+  },
+  {
+    "instanceId": "b",
+    "packages": [{"id": "Microsoft.VisualStudio.Component.Azure.ClientLibs", "type": "Component"}]
+  }
+]`}
+
+	res, err := NewFinder(WithFinderRunner(r)).FindAzure(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "a", res[0].Installation.InstanceID)
+	require.Equal(t, []string{AzureClientLibsComponent}, res[0].Components)
+	require.True(t, res[0].HasComponent(AzureClientLibsComponent))
+	require.False(t, res[0].HasComponent(AzureStorageEmulatorComponent))
+	require.Equal(t, []string{"-include", "packages", "-format", "json"}, r.args)
+}
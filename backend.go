@@ -0,0 +1,322 @@
+package vswhere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rfratto/vswhere/internal/filever"
+)
+
+// Backend is a source of installations.
+type Backend int
+
+// Supported backends.
+const (
+	// BackendVswhere runs vswhere.exe, or the Finder's Runner if one was
+	// given. It is unavailable if vswhere.exe isn't installed.
+	BackendVswhere Backend = iota
+
+	// BackendSetupConfiguration queries the Setup Configuration COM API used
+	// by vswhere. It is unavailable on platforms other than Windows and
+	// doesn't support WithRequires.
+	BackendSetupConfiguration
+
+	// BackendStateFiles reads the Visual Studio Installer's state files; see
+	// ReadStateDir. It is unavailable if the state directory doesn't exist
+	// and doesn't support WithRequires.
+	BackendStateFiles
+
+	// BackendRegistry reads Visual Studio 2010 through 2015 from the
+	// registry; see LegacyInstallations. It is only queried with WithLegacy
+	// and never answers a query on its own: its results are added to those
+	// of the other backends.
+	BackendRegistry
+)
+
+// DefaultBackends are the backends used by a Finder unless WithBackends is
+// given.
+var DefaultBackends = []Backend{BackendVswhere, BackendSetupConfiguration}
+
+// String returns the name of the backend.
+func (b Backend) String() string {
+	switch b {
+	case BackendVswhere:
+		return "vswhere"
+	case BackendSetupConfiguration:
+		return "setup configuration"
+	case BackendStateFiles:
+		return "state files"
+	case BackendRegistry:
+		return "registry"
+	default:
+		return fmt.Sprintf("Backend(%d)", int(b))
+	}
+}
+
+// errBackendUnavailable is returned by backends which can't be used on the
+// current machine or for the current query.
+var errBackendUnavailable = errors.New("backend unavailable")
+
+func isUnavailable(err error) bool {
+	return errors.Is(err, errBackendUnavailable) || errors.Is(err, ErrUnsupportedPlatform)
+}
+
+// WithBackends sets the backends a Finder queries, in order. The first
+// available backend answers the query and the rest are skipped, except for
+// BackendRegistry, whose results are always added. Installations found by
+// more than one backend are reported once, from the earliest backend, and
+// WithLatest applies to the combined results.
+// RunInfo.Sources reports which backend found each installation.
+func WithBackends(backends ...Backend) FinderOption {
+	return func(f *Finder) { f.backends = backends }
+}
+
+// query runs the backends of the Finder, returning the combined JSON array of
+// installations.
+func (f *Finder) query(ctx context.Context, so *searchOptions, args []string, info *RunInfo) ([]byte, error) {
+	backends := f.backends
+	if backends == nil {
+		backends = DefaultBackends
+	}
+
+	var (
+		results  = []json.RawMessage{}
+		keys     []installKey
+		answered bool
+		lastErr  error
+	)
+	for _, b := range backends {
+		if answered && b != BackendRegistry {
+			continue
+		}
+
+		start := time.Now()
+		raw, err := f.queryBackend(ctx, b, so, args, info)
+		if isUnavailable(err) {
+			f.logf("%s backend unavailable: %s", b, err)
+			info.Unavailable[b] = err
+			lastErr = err
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		f.logf("queried %s backend in %s", b, time.Since(start))
+		if b != BackendRegistry {
+			answered = true
+			info.Backend = b
+		}
+
+		for _, r := range raw {
+			var key installKey
+			if err := json.Unmarshal(r, &key); err != nil {
+				return nil, fmt.Errorf("failed parsing output of %s backend: %w", b, err)
+			}
+			if _, seen := info.Sources[key.InstanceID]; seen {
+				continue
+			}
+			if so.installPath != "" && !containsPath(key.InstallationPath, so.installPath) {
+				continue
+			}
+			info.Sources[key.InstanceID] = b
+			results = append(results, r)
+			keys = append(keys, key)
+		}
+	}
+	if !answered && len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	// Order results the same way regardless of which backends answered.
+	idx := make([]int, len(results))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return keys[idx[i]].less(keys[idx[j]], so.sort) })
+
+	// Each backend applies WithLatest to its own results, so only the newest
+	// of the combined results is kept.
+	if so.latest && len(idx) > 1 {
+		newest := 0
+		for i := range idx {
+			if keys[idx[i]].less(keys[idx[newest]], true) {
+				newest = i
+			}
+		}
+		idx = idx[newest : newest+1]
+	}
+
+	sorted := make([]json.RawMessage, len(idx))
+	for i, n := range idx {
+		sorted[i] = results[n]
+	}
+	return json.Marshal(sorted)
+}
+
+func (f *Finder) queryBackend(ctx context.Context, b Backend, so *searchOptions, args []string, info *RunInfo) ([]json.RawMessage, error) {
+	if len(so.extraArgs) > 0 && b != BackendVswhere {
+		return nil, fmt.Errorf("%w: WithExtraArgs is only supported by vswhere", errBackendUnavailable)
+	}
+
+	var (
+		installs []Installation
+		err      error
+	)
+	switch b {
+	case BackendVswhere:
+		return f.runVswhere(ctx, so, args, info)
+	case BackendSetupConfiguration:
+		installs, err = setupInstances(so)
+	case BackendStateFiles:
+		installs, err = stateInstallations(f.stateDir, f.imageRoot, so)
+	case BackendRegistry:
+		installs, err = registryInstallations(so)
+	default:
+		return nil, fmt.Errorf("unknown backend %s", b)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]json.RawMessage, 0, len(installs))
+	for _, install := range installs {
+		bb, err := json.Marshal(install)
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, bb)
+	}
+	return raw, nil
+}
+
+// runVswhere runs vswhere and returns the installations it found.
+func (f *Finder) runVswhere(ctx context.Context, so *searchOptions, args []string, info *RunInfo) ([]json.RawMessage, error) {
+	stdout, err := f.execVswhere(ctx, so, args, info)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(stdout, &raw); err != nil {
+		return nil, fmt.Errorf("failed parsing output of vswhere: %w", err)
+	}
+	return raw, nil
+}
+
+// execVswhere runs vswhere with args and returns its stdout, recording the
+// executable and exit code into info.
+func (f *Finder) execVswhere(ctx context.Context, so *searchOptions, args []string, info *RunInfo) ([]byte, error) {
+	runner, err := f.vswhereRunner(so)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version of a custom Runner is unknown, so its flags aren't gated.
+	var exeVersion string
+	if r, ok := runner.(execRunner); ok {
+		exeVersion, _ = filever.Get(r.path)
+	}
+	if args, err = gateFlags(exeVersion, args); err != nil {
+		return nil, err
+	}
+	utf8 := supportsFlag(exeVersion, "-utf8")
+	if utf8 {
+		args = append(args[:len(args):len(args)], "-utf8")
+	}
+	info.Args = args
+
+	stdout, stderr, err := runner.Run(ctx, args)
+	f.logf("ran vswhere %v", args)
+
+	var exitErr *exec.ExitError
+	if r, ok := runner.(execRunner); ok {
+		info.Path = r.path
+	}
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		info.ExitCode = exitErr.ExitCode()
+	default:
+		info.ExitCode = -1
+	}
+	if err != nil {
+		return nil, &ExecError{
+			Path:     info.Path,
+			Args:     args,
+			ExitCode: info.ExitCode,
+			Stderr:   string(stderr),
+			Err:      err,
+		}
+	}
+	if !utf8 {
+		stdout = decodeOutput(stdout)
+	}
+	info.Backend = BackendVswhere
+	return stdout, nil
+}
+
+// vswhereRunner returns the Runner used to run vswhere for the search.
+func (f *Finder) vswhereRunner(so *searchOptions) (Runner, error) {
+	if f.runner != nil {
+		return f.runner, nil
+	}
+
+	vsWherePath := so.executable
+	if vsWherePath == "" {
+		vsWherePath = f.executable
+	}
+	if vsWherePath == "" {
+		vsWherePath = os.Getenv("VSWHERE_PATH")
+	}
+	if vsWherePath == "" {
+		vsWherePath = filepath.Join(installerDir(), "vswhere.exe")
+		if _, err := os.Stat(vsWherePath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w at %s", ErrVswhereNotFound, vsWherePath)
+		}
+	}
+	return execRunner{path: vsWherePath, env: f.env}, nil
+}
+
+// readLegacy reads the legacy installations for the registry backend. It is
+// replaced in tests.
+var readLegacy = LegacyInstallations
+
+// registryInstallations returns the legacy installations matching so. Like
+// vswhere, legacy installations are only reported with WithLegacy. They're
+// always complete and never prerelease, so WithAll and WithPrerelease don't
+// affect them.
+func registryInstallations(so *searchOptions) ([]Installation, error) {
+	if !so.legacy || len(so.products) > 0 || len(so.requires) > 0 {
+		// Legacy installations have no product or components.
+		return nil, nil
+	}
+	legacy, err := readLegacy()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Installation
+	for _, install := range legacy {
+		if so.path != "" {
+			if containsPath(install.InstallationPath, so.path) {
+				res = append(res, install)
+			}
+			continue
+		}
+		if so.version != "" {
+			if ok, err := inVersionRange(install.InstallationVersion, so.version); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		res = append(res, install)
+	}
+	return res, nil
+}
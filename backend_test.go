@@ -0,0 +1,87 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBackends(t *testing.T) {
+	dir := t.TempDir()
+	writeState(t, dir, `{"installationVersion": "17.8.34322.80", "product": {"id": "Microsoft.VisualStudio.Product.Community"}}`, "fromstate")
+	r := &fakeRunner{stdout: `[{"instanceId": "fromvswhere"}]`}
+
+	// Unavailable backends are skipped.
+	var info RunInfo
+	f := NewFinder(
+		WithFinderRunner(r),
+		WithFinderOffline(filepath.Join(dir, "missing")),
+		WithBackends(BackendStateFiles, BackendVswhere, BackendSetupConfiguration),
+	)
+	installs, err := f.Find(context.Background(), WithRunInfo(&info))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Equal(t, "fromvswhere", installs[0].InstanceID)
+	require.Equal(t, map[string]Backend{"fromvswhere": BackendVswhere}, info.Sources)
+	require.Equal(t, BackendVswhere, info.Backend)
+	require.Contains(t, info.Unavailable, BackendStateFiles)
+
+	// No backend answers if all are unavailable.
+	_, err = NewFinder(WithFinderOffline(filepath.Join(dir, "missing")), WithBackends(BackendStateFiles)).
+		Find(context.Background(), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, Backend(-1), info.Backend)
+	require.Contains(t, info.Unavailable, BackendStateFiles)
+
+	// The first available backend answers.
+	f = NewFinder(
+		WithFinderRunner(r),
+		WithFinderOffline(dir),
+		WithBackends(BackendStateFiles, BackendVswhere),
+	)
+	installs, err = f.Find(context.Background(), WithRunInfo(&info))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Equal(t, "fromstate", installs[0].InstanceID)
+	require.Equal(t, map[string]Backend{"fromstate": BackendStateFiles}, info.Sources)
+	require.Equal(t, BackendStateFiles, info.Backend)
+	require.Empty(t, info.Unavailable)
+
+	// Backends which can't handle the query are skipped too.
+	installs, err = f.Find(context.Background(), WithRequires([]string{"Microsoft.VisualStudio.Workload.NativeDesktop"}))
+	require.NoError(t, err)
+	require.Equal(t, "fromvswhere", installs[0].InstanceID)
+	installs, err = f.Find(context.Background(), WithExtraArgs("-nocolor"))
+	require.NoError(t, err)
+	require.Equal(t, "fromvswhere", installs[0].InstanceID)
+}
+
+func TestRegistryBackend(t *testing.T) {
+	prev := readLegacy
+	readLegacy = func() ([]Installation, error) {
+		return []Installation{{InstanceID: "VisualStudio.14.0", InstallationVersion: "14.0"}}, nil
+	}
+	t.Cleanup(func() { readLegacy = prev })
+
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "installationVersion": "17.9.34607.119"},
+  {"instanceId": "b", "installationVersion": "16.11.34601.136"}
+]`}
+	f := NewFinder(WithFinderRunner(r), WithBackends(BackendVswhere, BackendRegistry))
+
+	installs, err := f.Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, installs, 2)
+
+	installs, err = f.Find(context.Background(), WithLegacy(true))
+	require.NoError(t, err)
+	require.Len(t, installs, 3)
+	require.Equal(t, "VisualStudio.14.0", installs[2].InstanceID)
+
+	installs, err = f.Find(context.Background(), WithLegacy(true), WithLatest(true))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Equal(t, "a", installs[0].InstanceID)
+}
@@ -0,0 +1,172 @@
+package vswhere
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SupportBundle writes a zip archive to w holding everything needed to
+// diagnose a problem finding Visual Studio, ready to attach to an issue. Every
+// product is included, such as Build Tools, along with incomplete and
+// prerelease installations:
+//
+//	report.md            the machine report from Report
+//	installations.json   installations found, redacted with Redacted
+//	vswhere.json         the output of vswhere, including fields this package
+//	                     doesn't model
+//	logs/dd_*.log        installer logs from the temporary directory
+//	errors.txt           anything which couldn't be collected
+//
+// Every file is redacted the same way as Installation.Redacted: nicknames and
+// campaign IDs are removed and user names in paths are replaced. Failing to
+// collect one part of the bundle doesn't fail the others; only errors writing
+// to w are returned.
+func SupportBundle(ctx context.Context, w io.Writer) error {
+	return Default().SupportBundle(ctx, w)
+}
+
+// SupportBundle writes a support bundle using the Finder's configuration.
+// See the package-level SupportBundle for details.
+func (f *Finder) SupportBundle(ctx context.Context, w io.Writer) error {
+	var (
+		zw       = zip.NewWriter(w)
+		failures []string
+	)
+
+	installs, err := f.Find(ctx, WithAll(true), WithPrerelease(true), WithProducts([]string{"*"}))
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("finding installations: %s", err))
+	}
+	redacted := make([]Installation, len(installs))
+	for i, install := range installs {
+		redacted[i] = install.Redacted()
+	}
+
+	report, err := Report(redacted, ReportOptions{})
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("rendering report: %s", err))
+	} else if err := writeZipFile(zw, "report.md", []byte(report)); err != nil {
+		return err
+	}
+
+	snapshot, err := json.MarshalIndent(redacted, "", "  ")
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("encoding installations: %s", err))
+	} else if err := writeZipFile(zw, "installations.json", snapshot); err != nil {
+		return err
+	}
+
+	raw, err := f.RunRaw(ctx, "-all", "-prerelease", "-products", "*", "-format", "json")
+	if err == nil {
+		raw, err = redactJSON(raw)
+	}
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("running vswhere: %s", err))
+	} else if err := writeZipFile(zw, "vswhere.json", raw); err != nil {
+		return err
+	}
+
+	logs, err := filepath.Glob(filepath.Join(os.TempDir(), "dd_*.log"))
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("listing installer logs: %s", err))
+	}
+	for _, path := range logs {
+		if err := copyZipFile(zw, "logs/"+filepath.Base(path), path); err != nil {
+			failures = append(failures, fmt.Sprintf("reading installer log: %s", err))
+		}
+	}
+
+	if len(failures) > 0 {
+		if err := writeZipFile(zw, "errors.txt", []byte(strings.Join(failures, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	return nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	return nil
+}
+
+// copyZipFile copies the text file at path into zw as name, redacting user
+// names in paths on each line.
+func copyZipFile(zw *zip.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if _, werr := io.WriteString(fw, redactPath(line)); werr != nil {
+			return werr
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// redactJSON redacts the JSON output of vswhere like Installation.Redacted,
+// keeping fields which Installation doesn't model.
+func redactJSON(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed parsing output of vswhere: %w", err)
+	}
+	return json.MarshalIndent(redactValue(v), "", "  ")
+}
+
+// redactValue redacts every string within v, a value decoded from JSON.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			switch k {
+			case "nickname", "campaignId":
+				v[k] = ""
+			default:
+				v[k] = redactValue(e)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+		return v
+	case string:
+		return redactPath(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,65 @@
+package vswhere
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportBundle(t *testing.T) {
+	temp := t.TempDir()
+	for _, key := range []string{"TMPDIR", "TMP", "TEMP"} {
+		setenv(t, key, temp)
+	}
+	log := "[1a2c:0001][2024-01-01T10:00:00] Log file: C:\\Users\\alice\\AppData\\Local\\Temp\\dd_setup_20240101.log\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(temp, "dd_setup_20240101.log"), []byte(log), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(temp, "unrelated.log"), []byte("other"), 0644))
+
+	r := &fakeRunner{stdout: `[{
+  "instanceId": "a",
+  "installationPath": "C:\\Users\\alice\\VS",
+  "installationVersion": "17.9.34607.119",
+  "properties": {"nickname": "alice's VS"},
+  "errors": {"failedPackages": [{"id": "Foo", "logFilePath": "C:\\Users\\alice\\AppData\\Local\\Temp\\dd_setup_20240101_001.log"}]},
+  "futureField": "kept"
+}]`}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewFinder(WithFinderRunner(r)).SupportBundle(context.Background(), &buf))
+	require.Equal(t, []string{"-all", "-prerelease", "-products", "*", "-format", "json"}, r.args)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		bb, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(bb)
+	}
+
+	require.ElementsMatch(t, []string{"report.md", "installations.json", "vswhere.json", "logs/dd_setup_20240101.log"}, keys(files))
+	for name, contents := range files {
+		require.NotContains(t, contents, "alice", name)
+	}
+	require.Contains(t, files["installations.json"], `C:\\Users\\REDACTED\\VS`)
+	require.Contains(t, files["vswhere.json"], `C:\\Users\\REDACTED\\VS`)
+	require.Contains(t, files["vswhere.json"], `"futureField": "kept"`)
+	require.Contains(t, files["logs/dd_setup_20240101.log"], `C:\Users\REDACTED\AppData`)
+}
+
+func keys(m map[string]string) []string {
+	var kk []string
+	for k := range m {
+		kk = append(kk, k)
+	}
+	return kk
+}
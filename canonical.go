@@ -0,0 +1,28 @@
+package vswhere
+
+// WithFinderCanonicalPaths rewrites the paths of each installation to their
+// canonical local form, resolving junctions and symbolic links and replacing
+// subst drives with the directory they refer to. Mapped network drives are
+// rewritten to UNC paths. Paths which can't be resolved, such as those of
+// installations that are no longer on disk, are left unchanged.
+//
+// Some images relocate Visual Studio through junctions, which breaks code
+// that joins paths relative to the reported installation path.
+func WithFinderCanonicalPaths() FinderOption {
+	return WithFinderTransformer(canonicalPaths)
+}
+
+func canonicalPaths(installs []Installation) []Installation {
+	for i := range installs {
+		install := &installs[i]
+		for _, p := range []*string{&install.InstallationPath, &install.ProductPath, &install.EnginePath} {
+			if *p == "" {
+				continue
+			}
+			if resolved, err := canonicalPath(*p); err == nil {
+				*p = resolved
+			}
+		}
+	}
+	return installs
+}
@@ -0,0 +1,10 @@
+//+build !windows
+
+package vswhere
+
+import "path/filepath"
+
+// canonicalPath returns path with symbolic links resolved.
+func canonicalPath(path string) (string, error) {
+	return filepath.EvalSymlinks(path)
+}
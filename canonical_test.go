@@ -0,0 +1,29 @@
+package vswhere
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalPaths(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "relocated")
+	require.NoError(t, os.Mkdir(target, 0755))
+	link := filepath.Join(dir, "VS")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("can't create symlinks: %s", err)
+	}
+	target, err := filepath.EvalSymlinks(target)
+	require.NoError(t, err)
+
+	missing := filepath.Join(dir, "missing")
+	installs := canonicalPaths([]Installation{
+		{InstallationPath: link},
+		{InstallationPath: missing},
+	})
+	require.Equal(t, target, installs[0].InstallationPath)
+	require.Equal(t, missing, installs[1].InstallationPath)
+}
@@ -0,0 +1,50 @@
+//+build windows
+
+package vswhere
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// volumeNameDOS requests a path with a drive letter or UNC prefix from
+// GetFinalPathNameByHandle.
+const volumeNameDOS = 0x0
+
+// canonicalPath returns the final path of the file or directory at path.
+func canonicalPath(path string) (string, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return "", err
+	}
+	// FILE_FLAG_BACKUP_SEMANTICS is required to open directories.
+	h, err := windows.CreateFile(name, 0, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE, nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(h)
+
+	buf := make([]uint16, windows.MAX_PATH)
+	for {
+		n, err := windows.GetFinalPathNameByHandle(h, &buf[0], uint32(len(buf)), volumeNameDOS)
+		if err != nil {
+			return "", err
+		}
+		if n < uint32(len(buf)) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]uint16, n)
+	}
+
+	final := windows.UTF16ToString(buf)
+	switch {
+	case strings.HasPrefix(final, `\\?\UNC\`):
+		return `\\` + final[len(`\\?\UNC\`):], nil
+	case strings.HasPrefix(final, `\\?\`):
+		return final[len(`\\?\`):], nil
+	default:
+		return final, nil
+	}
+}
@@ -0,0 +1,135 @@
+package vswhere
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/rfratto/vswhere/winsdk"
+)
+
+// workloads holds the IDs of the workloads reported by Capabilities.
+var workloads = []string{
+	"Microsoft.VisualStudio.Workload.Azure",
+	"Microsoft.VisualStudio.Workload.Data",
+	"Microsoft.VisualStudio.Workload.ManagedDesktop",
+	"Microsoft.VisualStudio.Workload.ManagedDesktopBuildTools",
+	"Microsoft.VisualStudio.Workload.MSBuildTools",
+	"Microsoft.VisualStudio.Workload.NativeCrossPlat",
+	"Microsoft.VisualStudio.Workload.NativeDesktop",
+	"Microsoft.VisualStudio.Workload.NativeGame",
+	"Microsoft.VisualStudio.Workload.NativeMobile",
+	"Microsoft.VisualStudio.Workload.NetCrossPlat",
+	"Microsoft.VisualStudio.Workload.NetWeb",
+	"Microsoft.VisualStudio.Workload.Node",
+	"Microsoft.VisualStudio.Workload.Office",
+	"Microsoft.VisualStudio.Workload.Python",
+	"Microsoft.VisualStudio.Workload.Universal",
+	"Microsoft.VisualStudio.Workload.UniversalBuildTools",
+	"Microsoft.VisualStudio.Workload.VCTools",
+	"Microsoft.VisualStudio.Workload.VisualStudioExtension",
+	"Microsoft.VisualStudio.Workload.WebBuildTools",
+}
+
+// Capability describes what an installation can build.
+type Capability struct {
+	Installation Installation
+
+	// Toolsets holds the versions of the installed MSVC toolsets, newest
+	// first.
+	Toolsets []string
+
+	// WindowsSDKs holds the Windows SDK versions installed on the machine.
+	// Windows SDKs are shared by all installations.
+	WindowsSDKs []string
+
+	// Workloads holds the IDs of the installed workloads, such as
+	// "Microsoft.VisualStudio.Workload.NativeDesktop".
+	Workloads []string
+
+	// Architectures holds the architectures that any installed MSVC toolset
+	// can target.
+	Architectures []Arch
+}
+
+// Capabilities returns the capabilities of all installations matching
+// options, suitable for matching build jobs to machines in one query.
+// Workloads are read from the packages of each installation, so vswhere
+// 2.8 or later is needed when vswhere is used.
+func Capabilities(ctx context.Context, options ...Option) ([]Capability, error) {
+	return Default().Capabilities(ctx, options...)
+}
+
+// Capabilities returns the capabilities of all installations matching
+// options using the Finder.
+func (f *Finder) Capabilities(ctx context.Context, options ...Option) ([]Capability, error) {
+	installs, err := f.Find(ctx, append(options, WithIncludePackages(true))...)
+	if err != nil {
+		return nil, err
+	}
+	// A machine without a Windows SDK simply reports none.
+	sdks, _ := winsdk.Versions()
+
+	res := make([]Capability, 0, len(installs))
+	for _, install := range installs {
+		c, err := capabilityOf(install)
+		if err != nil {
+			return nil, err
+		}
+		c.WindowsSDKs = sdks
+		c.Workloads = installedPackages(install, workloads)
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func capabilityOf(install Installation) (Capability, error) {
+	toolsets, err := Toolsets(install)
+	if err != nil {
+		return Capability{}, err
+	}
+
+	c := Capability{Installation: install}
+	for _, t := range toolsets {
+		c.Toolsets = append(c.Toolsets, t.Version)
+		for _, arch := range t.targets() {
+			if !hasArch(c.Architectures, arch) {
+				c.Architectures = append(c.Architectures, arch)
+			}
+		}
+	}
+	return c, nil
+}
+
+// targets returns the architectures the toolset can target from any host.
+func (t Toolset) targets() []Arch {
+	hosts, _ := ioutil.ReadDir(filepath.Join(t.Path, "bin"))
+
+	var res []Arch
+	for _, host := range hosts {
+		if !host.IsDir() || !strings.HasPrefix(strings.ToLower(host.Name()), "host") {
+			continue
+		}
+		targets, _ := ioutil.ReadDir(filepath.Join(t.Path, "bin", host.Name()))
+		for _, target := range targets {
+			arch := Arch(strings.ToLower(target.Name()))
+			switch arch {
+			case X86, X64, ARM, ARM64:
+				if target.IsDir() && !hasArch(res, arch) {
+					res = append(res, arch)
+				}
+			}
+		}
+	}
+	return res
+}
+
+func hasArch(list []Arch, arch Arch) bool {
+	for _, a := range list {
+		if a == arch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityOf(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x86", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "arm64", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx86", "x86", "cl.exe")
+
+	c, err := capabilityOf(Installation{InstallationPath: root})
+	require.NoError(t, err)
+	require.Equal(t, []string{"14.38.33130", "14.29.30133"}, c.Toolsets)
+	require.ElementsMatch(t, []Arch{X64, ARM64, X86}, c.Architectures)
+}
+
+func TestFinderCapabilities(t *testing.T) {
+	root := filepath.ToSlash(t.TempDir())
+	r := &fakeRunner{stdout: `[{
+  "instanceId": "a",
+  "installationPath": "` + root + `",
+  "packages": [
+    {"id": "Microsoft.VisualStudio.Workload.VCTools", "type": "Workload"},
+    {"id": "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", "type": "Component"}
+  ]
+}]`}
+
+	caps, err := NewFinder(WithFinderRunner(r)).Capabilities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, caps, 1)
+	require.Equal(t, []string{"Microsoft.VisualStudio.Workload.VCTools"}, caps[0].Workloads)
+	require.Equal(t, []string{"-include", "packages", "-format", "json"}, r.args)
+}
@@ -0,0 +1,132 @@
+package vswhere
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds default search options, allowing a selection policy to be
+// shared across tools through a JSON or TOML file or environment variables.
+// The Finder returned by Default applies DefaultConfig to every query; use
+// WithFinderConfig to apply a Config to other Finders.
+type Config struct {
+	All         bool     `json:"all" toml:"all"`
+	Prerelease  bool     `json:"prerelease" toml:"prerelease"`
+	Products    []string `json:"products" toml:"products"`
+	Requires    []string `json:"requires" toml:"requires"`
+	RequiresAny bool     `json:"requiresAny" toml:"requiresAny"`
+	Version     string   `json:"version" toml:"version"`
+}
+
+// Options returns the search options described by c. Options given after
+// them to Find override the config.
+func (c Config) Options() []Option {
+	opts := []Option{
+		WithAll(c.All),
+		WithPrerelease(c.Prerelease),
+		WithRequiresAny(c.RequiresAny),
+		WithVersion(c.Version),
+	}
+	if len(c.Products) > 0 {
+		opts = append(opts, WithProducts(c.Products))
+	}
+	if len(c.Requires) > 0 {
+		opts = append(opts, WithRequires(c.Requires))
+	}
+	return opts
+}
+
+// LoadConfig reads a Config from the file at path. Files with a ".toml"
+// extension are read as TOML, using the same keys as JSON; other files are
+// read as JSON.
+func LoadConfig(path string) (Config, error) {
+	bb, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	var c Config
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		md, err := toml.Decode(string(bb), &c)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return Config{}, fmt.Errorf("invalid config %s: unknown key %q", path, undecoded[0].String())
+		}
+		return c, nil
+	}
+	if err := json.Unmarshal(bb, &c); err != nil {
+		return Config{}, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Environment variables read by DefaultConfig. Lists are separated by
+// semicolons and booleans use strconv.ParseBool syntax.
+const (
+	ConfigEnv       = "VSWHERE_CONFIG"
+	AllEnv          = "VSWHERE_ALL"
+	PrereleaseEnv   = "VSWHERE_PRERELEASE"
+	ProductsEnv     = "VSWHERE_PRODUCTS"
+	RequiresEnv     = "VSWHERE_REQUIRES"
+	RequiresAnyEnv  = "VSWHERE_REQUIRES_ANY"
+	VersionRangeEnv = "VSWHERE_VERSION_RANGE"
+)
+
+// DefaultConfig returns the machine's default config. The config is loaded
+// from the file named by VSWHERE_CONFIG if set, and then each of the other
+// VSWHERE_* variables that are set overrides the matching field.
+func DefaultConfig() (Config, error) {
+	var c Config
+	if path := os.Getenv(ConfigEnv); path != "" {
+		var err error
+		if c, err = LoadConfig(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for env, field := range map[string]*bool{
+		AllEnv:         &c.All,
+		PrereleaseEnv:  &c.Prerelease,
+		RequiresAnyEnv: &c.RequiresAny,
+	} {
+		v, ok := os.LookupEnv(env)
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", env, err)
+		}
+		*field = b
+	}
+	for env, field := range map[string]*[]string{
+		ProductsEnv: &c.Products,
+		RequiresEnv: &c.Requires,
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			*field = splitList(v)
+		}
+	}
+	if v, ok := os.LookupEnv(VersionRangeEnv); ok {
+		c.Version = v
+	}
+	return c, nil
+}
+
+func splitList(v string) []string {
+	var res []string
+	for _, e := range strings.Split(v, ";") {
+		if e = strings.TrimSpace(e); e != "" {
+			res = append(res, e)
+		}
+	}
+	return res
+}
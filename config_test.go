@@ -0,0 +1,115 @@
+package vswhere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vswhere.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{
+		"products": ["Microsoft.VisualStudio.Product.BuildTools"],
+		"version": "[16.0,17.0)",
+		"prerelease": true
+	}`), 0644))
+
+	setenv(t, ConfigEnv, path)
+	setenv(t, VersionRangeEnv, "[17.0,18.0)")
+	setenv(t, RequiresEnv, "A; B")
+
+	c, err := DefaultConfig()
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		Prerelease: true,
+		Products:   []string{"Microsoft.VisualStudio.Product.BuildTools"},
+		Requires:   []string{"A", "B"},
+		Version:    "[17.0,18.0)",
+	}, c)
+
+	args, err := BuildArgs(c.Options()...)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-prerelease",
+		"-products", "Microsoft.VisualStudio.Product.BuildTools",
+		"-requires", "A", "B",
+		"-version", "[17.0,18.0)",
+		"-format", "json",
+	}, args)
+
+	setenv(t, PrereleaseEnv, "maybe")
+	_, err = DefaultConfig()
+	require.Error(t, err)
+}
+
+func TestLoadConfigTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vswhere.toml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`# Build with the 2019 Build Tools.
+products = ["Microsoft.VisualStudio.Product.BuildTools"]
+requires = [
+  "A", # the first
+  'B\C',
+]
+version = "[16.0,17.0)"
+prerelease = true
+`), 0644))
+
+	c, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		Prerelease: true,
+		Products:   []string{"Microsoft.VisualStudio.Product.BuildTools"},
+		Requires:   []string{"A", `B\C`},
+		Version:    "[16.0,17.0)",
+	}, c)
+
+	for _, bad := range []string{
+		"[table]\nall = true",
+		"all = yes",
+		"all = true true",
+		`version = "[16.0`,
+		"all = true\nall = false",
+		"unknown = true",
+	} {
+		require.NoError(t, ioutil.WriteFile(path, []byte(bad), 0644))
+		_, err := LoadConfig(path)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestFinderConfig(t *testing.T) {
+	r := &fakeRunner{stdout: `[]`}
+	c := Config{Prerelease: true, Version: "[16.0,17.0)"}
+	_, err := NewFinder(WithFinderRunner(r), WithFinderConfig(c)).Find(context.Background(), WithVersion("[17.0,18.0)"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-prerelease", "-version", "[17.0,18.0)", "-format", "json"}, r.args)
+
+	// The default Finder reads the environment on every query.
+	f := newDefaultFinder()
+	f.runner = r
+	setenv(t, RequiresEnv, "A")
+	_, err = f.Find(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"-requires", "A", "-format", "json"}, r.args)
+
+	setenv(t, AllEnv, "maybe")
+	_, err = f.Find(context.Background())
+	require.Error(t, err)
+}
+
+// setenv sets an environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
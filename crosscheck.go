@@ -0,0 +1,162 @@
+package vswhere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Discrepancy is a difference between the installations reported by two or
+// more backends.
+type Discrepancy struct {
+	InstanceID string
+
+	// Field is the JSON name of the property whose value differs. It is
+	// empty if only some backends found the installation, in which case
+	// Values holds the instance ID for the backends which found it and an
+	// empty string for the others.
+	Field string
+
+	// Values holds the value reported by each backend which answered.
+	Values map[Backend]string
+}
+
+// CrossCheckResult is the result of CrossCheck.
+type CrossCheckResult struct {
+	// Backends are the backends which answered.
+	Backends []Backend
+
+	// Errors holds the error of each backend which couldn't be queried,
+	// such as vswhere not being installed.
+	Errors map[Backend]error
+
+	// Discrepancies are the differences found between the backends which
+	// answered, ordered by instance ID.
+	Discrepancies []Discrepancy
+}
+
+// crossCheckFields are the properties compared by CrossCheck. Paths are
+// compared case-insensitively.
+var crossCheckFields = []struct {
+	name  string
+	fold  bool
+	value func(Installation) string
+}{
+	{"installationPath", true, func(i Installation) string { return i.InstallationPath }},
+	{"installationVersion", false, func(i Installation) string { return i.InstallationVersion }},
+	{"productId", false, func(i Installation) string { return i.ProductID }},
+	{"isComplete", false, func(i Installation) string { return fmt.Sprint(i.IsComplete) }},
+	{"isLaunchable", false, func(i Installation) string { return fmt.Sprint(i.IsLaunchable) }},
+	{"isPrerelease", false, func(i Installation) string { return fmt.Sprint(i.IsPrerelease) }},
+}
+
+// CrossCheck queries every backend (vswhere, the Setup Configuration API, and
+// the installer's state files, plus the registry with WithLegacy) and reports
+// where their results disagree, to diagnose machines where vswhere's output
+// doesn't match what is installed. All products, incomplete installations,
+// and prereleases are included unless options say otherwise.
+func CrossCheck(ctx context.Context, options ...Option) (CrossCheckResult, error) {
+	return Default().CrossCheck(ctx, options...)
+}
+
+// CrossCheck queries every backend using the Finder and reports where their
+// results disagree.
+func (f *Finder) CrossCheck(ctx context.Context, options ...Option) (CrossCheckResult, error) {
+	options = append([]Option{WithAll(true), WithProducts([]string{"*"}), WithPrerelease(true)}, options...)
+	so, err := f.newSearchOptions(options)
+	if err != nil {
+		return CrossCheckResult{}, err
+	}
+	args, err := so.args()
+	if err != nil {
+		return CrossCheckResult{}, err
+	}
+
+	backends := []Backend{BackendVswhere, BackendSetupConfiguration, BackendStateFiles}
+	if so.legacy {
+		backends = append(backends, BackendRegistry)
+	}
+
+	res := CrossCheckResult{Errors: make(map[Backend]error)}
+	found := make(map[Backend]map[string]Installation)
+	for _, b := range backends {
+		out, err := f.record(ctx, &so, args, func(ctx context.Context, info *RunInfo) ([]byte, error) {
+			raw, err := f.queryBackend(ctx, b, &so, args, info)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(raw)
+		})
+		if err != nil {
+			f.logf("%s backend failed: %s", b, err)
+			res.Errors[b] = err
+			continue
+		}
+
+		var installs []Installation
+		if err := json.Unmarshal(out, &installs); err != nil {
+			res.Errors[b] = fmt.Errorf("failed parsing output of %s backend: %w", b, err)
+			continue
+		}
+		res.Backends = append(res.Backends, b)
+		found[b] = make(map[string]Installation, len(installs))
+		for _, install := range installs {
+			found[b][install.InstanceID] = install
+		}
+	}
+
+	res.Discrepancies = compareBackends(res.Backends, found)
+	return res, nil
+}
+
+func compareBackends(backends []Backend, found map[Backend]map[string]Installation) []Discrepancy {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, b := range backends {
+		for id := range found[b] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	var res []Discrepancy
+	for _, id := range ids {
+		presence := Discrepancy{InstanceID: id, Values: make(map[Backend]string)}
+		var missing bool
+		for _, b := range backends {
+			if _, ok := found[b][id]; ok {
+				presence.Values[b] = id
+			} else {
+				presence.Values[b] = ""
+				missing = true
+			}
+		}
+		if missing {
+			res = append(res, presence)
+		}
+
+		for _, field := range crossCheckFields {
+			d := Discrepancy{InstanceID: id, Field: field.name, Values: make(map[Backend]string)}
+			distinct := make(map[string]bool)
+			for _, b := range backends {
+				if install, ok := found[b][id]; ok {
+					v := field.value(install)
+					d.Values[b] = v
+					if field.fold {
+						v = strings.ToLower(v)
+					}
+					distinct[v] = true
+				}
+			}
+			if len(distinct) > 1 {
+				res = append(res, d)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinderCrossCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeState(t, dir, `{
+  "installationPath": "c:\\vs\\2022",
+  "installationVersion": "17.8.34322.80",
+  "product": {"id": "Microsoft.VisualStudio.Product.Community"}
+}`, "a")
+	writeState(t, dir, `{
+  "installationPath": "C:\\VS\\2019",
+  "installationVersion": "16.11.34601.136",
+  "product": {"id": "Microsoft.VisualStudio.Product.Community"}
+}`, "c")
+
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "isComplete": true, "isLaunchable": true, "installationPath": "C:\\VS\\2022", "installationVersion": "17.9.34607.119", "productId": "Microsoft.VisualStudio.Product.Community"},
+  {"instanceId": "b", "installationPath": "C:\\VS\\BuildTools", "installationVersion": "17.9.34607.119", "productId": "Microsoft.VisualStudio.Product.BuildTools"}
+]`}
+	f := NewFinder(WithFinderRunner(r), WithFinderOffline(dir))
+
+	res, err := f.CrossCheck(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"-all", "-prerelease", "-products", "*", "-format", "json"}, r.args)
+	require.Equal(t, []Backend{BackendVswhere, BackendStateFiles}, res.Backends)
+	require.Contains(t, res.Errors, BackendSetupConfiguration)
+	require.Equal(t, []Discrepancy{
+		{InstanceID: "a", Field: "installationVersion", Values: map[Backend]string{
+			BackendVswhere:    "17.9.34607.119",
+			BackendStateFiles: "17.8.34322.80",
+		}},
+		{InstanceID: "b", Values: map[Backend]string{BackendVswhere: "b", BackendStateFiles: ""}},
+		{InstanceID: "c", Values: map[Backend]string{BackendVswhere: "", BackendStateFiles: "c"}},
+	}, res.Discrepancies)
+}
@@ -0,0 +1,122 @@
+package encode
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/rfratto/vswhere"
+)
+
+// cdxBOM is the subset of a CycloneDX 1.5 JSON document written by
+// MarshalCycloneDX.
+type cdxBOM struct {
+	BOMFormat   string         `json:"bomFormat"`
+	SpecVersion string         `json:"specVersion"`
+	Version     int            `json:"version"`
+	Components  []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type        string         `json:"type"`
+	BOMRef      string         `json:"bom-ref,omitempty"`
+	Publisher   string         `json:"publisher,omitempty"`
+	Name        string         `json:"name"`
+	Version     string         `json:"version,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Properties  []cdxProperty  `json:"properties,omitempty"`
+	Components  []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MarshalCycloneDX encodes installations as a CycloneDX 1.5 JSON software
+// bill of materials. Each installation is an application component, with
+// its packages (see vswhere.WithIncludePackages) as nested components.
+// Fields without a CycloneDX equivalent, such as the installation path, are
+// written as properties prefixed with "vswhere:".
+//
+// The document has no timestamp or serial number so the same installations
+// always produce the same output; SBOM tools which require them should add
+// them.
+func MarshalCycloneDX(installs []vswhere.Installation) ([]byte, error) {
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Components:  []cdxComponent{},
+	}
+	for _, install := range installs {
+		name := install.DisplayName
+		if name == "" {
+			name = install.ProductID
+		}
+		c := cdxComponent{
+			Type:        "application",
+			BOMRef:      install.InstanceID,
+			Publisher:   "Microsoft Corporation",
+			Name:        name,
+			Version:     install.InstallationVersion,
+			Description: install.Description,
+			Properties: cdxProperties(
+				"vswhere:instanceId", install.InstanceID,
+				"vswhere:productId", install.ProductID,
+				"vswhere:installationPath", install.InstallationPath,
+				"vswhere:channelId", install.ChannelID,
+				"vswhere:productDisplayVersion", install.Catalog.ProductDisplayVersion,
+			),
+		}
+		refs := make(map[string]int)
+		for _, pkg := range install.Packages {
+			c.Components = append(c.Components, cdxComponent{
+				Type:    "library",
+				BOMRef:  packageRef(install.InstanceID, pkg, refs),
+				Name:    pkg.ID,
+				Version: pkg.Version,
+				Properties: cdxProperties(
+					"vswhere:type", pkg.Type,
+					"vswhere:chip", pkg.Chip,
+					"vswhere:language", pkg.Language,
+				),
+			})
+		}
+		bom.Components = append(bom.Components, c)
+	}
+	return json.MarshalIndent(bom, "", "  ")
+}
+
+// packageRef returns a bom-ref for a package of the installation instanceID.
+// The same package ID can be installed for several chips and languages, so
+// they're part of the reference; references seen before are numbered to
+// keep them unique.
+func packageRef(instanceID string, pkg vswhere.Package, seen map[string]int) string {
+	ref := instanceID + "/" + pkg.ID
+	if pkg.Version != "" {
+		ref += "@" + pkg.Version
+	}
+	for _, qualifier := range []string{pkg.Chip, pkg.Language} {
+		if qualifier != "" {
+			ref += ":" + qualifier
+		}
+	}
+
+	seen[ref]++
+	if n := seen[ref]; n > 1 {
+		ref += "#" + strconv.Itoa(n)
+	}
+	return ref
+}
+
+// cdxProperties builds properties from name/value pairs, skipping empty
+// values.
+func cdxProperties(pairs ...string) []cdxProperty {
+	var props []cdxProperty
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			props = append(props, cdxProperty{Name: pairs[i], Value: pairs[i+1]})
+		}
+	}
+	return props
+}
@@ -0,0 +1,165 @@
+package encode
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rfratto/vswhere"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var testInstalls = []vswhere.Installation{{
+	InstanceID:       "abc123",
+	InstallDate:      time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+	InstallationPath: `C:\Program Files\Microsoft Visual Studio\2019\Community`,
+	State:            4294967295,
+	IsComplete:       true,
+	Catalog:          vswhere.Catalog{ProductLineVersion: "2019"},
+	Properties:       vswhere.Properties{Nickname: "a <b> & \"c\""},
+}}
+
+func TestMarshalYAML(t *testing.T) {
+	out, err := MarshalYAML(testInstalls)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "- instanceId: abc123\n  installDate: 2021-03-04T05:06:07Z\n")
+	require.Contains(t, string(out), "  installationPath: C:\\Program Files\\Microsoft Visual Studio\\2019\\Community\n")
+	require.Contains(t, string(out), "  state: 4294967295\n  isComplete: true\n")
+	require.Contains(t, string(out), "  catalog:\n    buildBranch: \"\"\n")
+	require.Contains(t, string(out), "    nickname: a <b> & \"c\"\n")
+
+	out, err = MarshalYAML(nil)
+	require.NoError(t, err)
+	require.Equal(t, "[]\n", string(out))
+}
+
+func TestMarshalTOML(t *testing.T) {
+	out, err := MarshalTOML(testInstalls)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "[[installation]]\ninstanceId = \"abc123\"\ninstallDate = 2021-03-04T05:06:07Z\n")
+	require.Contains(t, string(out), "\n[installation.catalog]\nbuildBranch = \"\"\n")
+	require.Contains(t, string(out), "productLineVersion = \"2019\"\n")
+	require.Contains(t, string(out), "\n[installation.properties]\n")
+	require.Contains(t, string(out), "nickname = \"a <b> & \\\"c\\\"\"\n")
+}
+
+// roundTripInstalls exercises omitempty fields and lists of structs.
+var roundTripInstalls = []vswhere.Installation{
+	{
+		InstanceID:  "abc123",
+		InstallDate: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		State:       4294967295,
+		IsComplete:  true,
+		Packages: []vswhere.Package{
+			{ID: "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", Version: "17.8.34129.139", Type: "Component"},
+			{ID: "Microsoft.VisualCpp.CRT.Redist.X64", Chip: "x64", Language: "en-US", Type: "Vsix"},
+		},
+		Errors: vswhere.Errors{
+			FailedPackages: []vswhere.FailedPackage{{ID: "Failed", LogFilePath: `C:\Temp\dd_setup.log`}},
+		},
+	},
+	{InstanceID: "def456"},
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	out, err := MarshalYAML(roundTripInstalls)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "chip: \"\"")
+	require.NotContains(t, string(out), "packages: []")
+
+	var installs []vswhere.Installation
+	require.NoError(t, yaml.Unmarshal(out, &installs))
+	require.Equal(t, roundTripInstalls, installs)
+}
+
+func TestTOMLRoundTrip(t *testing.T) {
+	out, err := MarshalTOML(roundTripInstalls)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "chip = \"\"")
+
+	var doc struct {
+		Installations []vswhere.Installation `toml:"installation"`
+	}
+	_, err = toml.Decode(string(out), &doc)
+	require.NoError(t, err)
+	require.Equal(t, roundTripInstalls, doc.Installations)
+}
+
+func TestMarshalXML(t *testing.T) {
+	out, err := MarshalXML(testInstalls)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "<instances>\n  <instance>\n    <instanceId>abc123</instanceId>\n")
+	require.Contains(t, string(out), "<productLineVersion>2019</productLineVersion>")
+	require.Contains(t, string(out), "<nickname>a &lt;b&gt; &amp; &#34;c&#34;</nickname>")
+}
+
+func TestMarshalCycloneDX(t *testing.T) {
+	installs := []vswhere.Installation{{
+		InstanceID:          "abc123",
+		InstallationPath:    `C:\Program Files\Microsoft Visual Studio\2022\BuildTools`,
+		InstallationVersion: "17.8.34322.80",
+		ProductID:           "Microsoft.VisualStudio.Product.BuildTools",
+		DisplayName:         "Visual Studio Build Tools 2022",
+		Packages: []vswhere.Package{
+			{ID: "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", Version: "17.8.34129.139", Type: "Component"},
+		},
+	}}
+	out, err := MarshalCycloneDX(installs)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [{
+    "type": "application",
+    "bom-ref": "abc123",
+    "publisher": "Microsoft Corporation",
+    "name": "Visual Studio Build Tools 2022",
+    "version": "17.8.34322.80",
+    "properties": [
+      {"name": "vswhere:instanceId", "value": "abc123"},
+      {"name": "vswhere:productId", "value": "Microsoft.VisualStudio.Product.BuildTools"},
+      {"name": "vswhere:installationPath", "value": "C:\\Program Files\\Microsoft Visual Studio\\2022\\BuildTools"}
+    ],
+    "components": [{
+      "type": "library",
+      "bom-ref": "abc123/Microsoft.VisualStudio.Component.VC.Tools.x86.x64@17.8.34129.139",
+      "name": "Microsoft.VisualStudio.Component.VC.Tools.x86.x64",
+      "version": "17.8.34129.139",
+      "properties": [{"name": "vswhere:type", "value": "Component"}]
+    }]
+  }]
+}`, string(out))
+
+	// Packages sharing an ID still get unique references.
+	installs[0].Packages = []vswhere.Package{
+		{ID: "Microsoft.VisualCpp.CRT.Redist", Version: "14.38.33130", Chip: "x86"},
+		{ID: "Microsoft.VisualCpp.CRT.Redist", Version: "14.38.33130", Chip: "x64"},
+		{ID: "Microsoft.VisualCpp.CRT.Redist", Version: "14.38.33130", Chip: "x64"},
+	}
+	out, err = MarshalCycloneDX(installs)
+	require.NoError(t, err)
+	var bom struct {
+		Components []struct {
+			Components []struct {
+				BOMRef string `json:"bom-ref"`
+			} `json:"components"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(out, &bom))
+	var refs []string
+	for _, c := range bom.Components[0].Components {
+		refs = append(refs, c.BOMRef)
+	}
+	require.Equal(t, []string{
+		"abc123/Microsoft.VisualCpp.CRT.Redist@14.38.33130:x86",
+		"abc123/Microsoft.VisualCpp.CRT.Redist@14.38.33130:x64",
+		"abc123/Microsoft.VisualCpp.CRT.Redist@14.38.33130:x64#2",
+	}, refs)
+
+	out, err = MarshalCycloneDX(nil)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"components": []`)
+}
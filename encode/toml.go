@@ -0,0 +1,24 @@
+package encode
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rfratto/vswhere"
+)
+
+// MarshalTOML encodes installations as a TOML array of tables named
+// "installation".
+func MarshalTOML(installs []vswhere.Installation) ([]byte, error) {
+	doc := struct {
+		Installations []vswhere.Installation `toml:"installation"`
+	}{installs}
+
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
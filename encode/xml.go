@@ -0,0 +1,65 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+
+	"github.com/rfratto/vswhere"
+)
+
+// MarshalXML encodes installations as XML, using the same layout as
+// vswhere's "-format xml": an <instances> root with an <instance> element per
+// installation.
+func MarshalXML(installs []vswhere.Installation) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := writeXMLList(enc, reflect.ValueOf(installs), "instances", "instance"); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+func writeXMLList(enc *xml.Encoder, v reflect.Value, name, itemName string) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := writeXMLValue(enc, v.Index(i), itemName); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+func writeXMLValue(enc *xml.Encoder, v reflect.Value, name string) error {
+	if v.Kind() == reflect.Slice {
+		return writeXMLList(enc, v, name, strings.TrimSuffix(name, "s"))
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if isScalar(v) {
+		if err := enc.EncodeToken(xml.CharData(formatScalar(v))); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range fields(v) {
+			if err := writeXMLValue(enc, f.Value, f.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
@@ -0,0 +1,26 @@
+package encode
+
+import (
+	"bytes"
+
+	"github.com/rfratto/vswhere"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML encodes installations as a YAML sequence.
+func MarshalYAML(installs []vswhere.Installation) ([]byte, error) {
+	if installs == nil {
+		installs = []vswhere.Installation{}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(installs); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,17 @@
+package vswhere
+
+import "unicode/utf8"
+
+// decodeOutput converts output from vswhere written without -utf8 to UTF-8.
+// vswhere writes in the console's OEM code page by default.
+// Output which is already valid UTF-8, such as plain ASCII, is returned
+// unchanged.
+func decodeOutput(out []byte) []byte {
+	if utf8.Valid(out) {
+		return out
+	}
+	if converted, err := oemToUTF8(out); err == nil {
+		return converted
+	}
+	return out
+}
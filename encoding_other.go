@@ -0,0 +1,9 @@
+//+build !windows
+
+package vswhere
+
+// oemToUTF8 returns ErrUnsupportedPlatform; OEM code pages only exist on
+// Windows.
+func oemToUTF8(b []byte) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
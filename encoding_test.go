@@ -0,0 +1,12 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeOutput(t *testing.T) {
+	out := []byte(`[{"displayName": "Visual Studio Professional 2022", "properties": {"nickname": "Équipe"}}]`)
+	require.Equal(t, out, decodeOutput(out))
+}
@@ -0,0 +1,29 @@
+//+build windows
+
+package vswhere
+
+import (
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGetOEMCP = modkernel32.NewProc("GetOEMCP")
+
+// oemToUTF8 converts b from the active OEM code page to UTF-8.
+func oemToUTF8(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	cp, _, _ := procGetOEMCP.Call()
+
+	n, err := windows.MultiByteToWideChar(uint32(cp), 0, &b[0], int32(len(b)), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	wide := make([]uint16, n)
+	if _, err := windows.MultiByteToWideChar(uint32(cp), 0, &b[0], int32(len(b)), &wide[0], n); err != nil {
+		return nil, err
+	}
+	return []byte(string(utf16.Decode(wide))), nil
+}
@@ -0,0 +1,134 @@
+package vswhere
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The vswhere release downloaded by EnsureVswhere when EnsureOptions doesn't
+// name one, and the SHA-256 checksum of its vswhere.exe.
+const (
+	DefaultVswhereVersion = "3.1.7"
+	DefaultVswhereSHA256  = "c54f3b7c9164ea9a0db8641e81ecdda80c2664ef5a47c4191406f848cc07c662"
+)
+
+// EnsureOptions configures EnsureVswhere.
+type EnsureOptions struct {
+	// Version is the vswhere release to download (e.g., "3.1.7"). Defaults
+	// to DefaultVswhereVersion.
+	Version string
+
+	// SHA256 is the expected hex-encoded SHA-256 checksum of vswhere.exe for
+	// Version. Defaults to DefaultVswhereSHA256, and is required if Version
+	// is given.
+	SHA256 string
+
+	// CacheDir is where downloaded releases are kept. Defaults to
+	// "%LOCALAPPDATA%\vswhere".
+	CacheDir string
+
+	// URL overrides where vswhere.exe is downloaded from. Defaults to the
+	// GitHub release of Version.
+	URL string
+
+	// Client is used to download vswhere.exe. Defaults to
+	// http.DefaultClient.
+	Client *http.Client
+}
+
+// EnsureVswhere returns the path to a cached copy of the vswhere.exe release
+// described by opts, downloading it first if needed. The download is rejected
+// if its checksum doesn't match opts.SHA256. The returned path can be given to
+// WithExecutable or WithFinderExecutable.
+func EnsureVswhere(ctx context.Context, opts EnsureOptions) (string, error) {
+	switch {
+	case opts.Version == "" && opts.SHA256 == "":
+		opts.Version, opts.SHA256 = DefaultVswhereVersion, DefaultVswhereSHA256
+	case opts.Version == "" || opts.SHA256 == "":
+		return "", fmt.Errorf("EnsureVswhere requires both a Version and SHA256, or neither")
+	}
+	if opts.CacheDir == "" {
+		opts.CacheDir = filepath.Join(os.Getenv("LOCALAPPDATA"), "vswhere")
+	}
+	if opts.URL == "" {
+		opts.URL = "https://github.com/microsoft/vswhere/releases/download/" + opts.Version + "/vswhere.exe"
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	dir := filepath.Join(opts.CacheDir, opts.Version)
+	path := filepath.Join(dir, "vswhere.exe")
+	if sum, err := fileSHA256(path); err == nil && strings.EqualFold(sum, opts.SHA256) {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := ioutil.TempFile(dir, "vswhere-*.exe")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	sum, err := download(ctx, opts.Client, opts.URL, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to download vswhere %s: %w", opts.Version, err)
+	}
+	if !strings.EqualFold(sum, opts.SHA256) {
+		return "", fmt.Errorf("checksum mismatch for vswhere %s: expected %s, got %s", opts.Version, opts.SHA256, sum)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to cache vswhere: %w", err)
+	}
+	return path, nil
+}
+
+// download writes the body of url to w, returning its hex-encoded SHA-256.
+func download(ctx context.Context, client *http.Client, url string, w io.Writer) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
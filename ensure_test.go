@@ -0,0 +1,69 @@
+package vswhere
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureVswhere(t *testing.T) {
+	content := []byte("not really vswhere")
+	sum := sha256.Sum256(content)
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	opts := EnsureOptions{
+		Version:  "3.1.7",
+		SHA256:   hex.EncodeToString(sum[:]),
+		CacheDir: t.TempDir(),
+		URL:      srv.URL,
+	}
+
+	path, err := EnsureVswhere(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(opts.CacheDir, "3.1.7", "vswhere.exe"), path)
+	bb, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, content, bb)
+
+	// The cached copy is reused.
+	_, err = EnsureVswhere(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+
+	opts.Version = "3.1.1"
+	opts.SHA256 = "0000"
+	_, err = EnsureVswhere(context.Background(), opts)
+	require.Error(t, err)
+	require.NoFileExists(t, filepath.Join(opts.CacheDir, "3.1.1", "vswhere.exe"))
+
+	opts.SHA256 = ""
+	_, err = EnsureVswhere(context.Background(), opts)
+	require.Error(t, err)
+}
+
+func TestEnsureVswhereDefault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not really vswhere"))
+	}))
+	defer srv.Close()
+
+	// The pinned release is checked against the pinned checksum.
+	cache := t.TempDir()
+	_, err := EnsureVswhere(context.Background(), EnsureOptions{CacheDir: cache, URL: srv.URL})
+	require.EqualError(t, err, "checksum mismatch for vswhere "+DefaultVswhereVersion+": expected "+DefaultVswhereSHA256+
+		", got ce00051d9fc2abe3d7d9ec11f26edb54efb9ddda45925027493abd0fa5a309aa")
+	require.DirExists(t, filepath.Join(cache, DefaultVswhereVersion))
+}
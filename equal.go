@@ -0,0 +1,52 @@
+package vswhere
+
+import (
+	"reflect"
+	"time"
+)
+
+type equalOptions struct {
+	ignoreUpdateDate bool
+	ignoreState      bool
+}
+
+// EqualOption customizes how installations are compared by Equal.
+type EqualOption func(eo *equalOptions)
+
+// IgnoreUpdateDate ignores UpdateDate when comparing installations.
+func IgnoreUpdateDate() EqualOption {
+	return func(eo *equalOptions) { eo.ignoreUpdateDate = true }
+}
+
+// IgnoreState ignores the State bits and IsRebootRequired when comparing
+// installations. These change as the installer works on an instance without
+// the instance itself changing.
+func IgnoreState() EqualOption {
+	return func(eo *equalOptions) { eo.ignoreState = true }
+}
+
+// Equal returns true if i and other describe the same installation. Times are
+// compared by instant rather than by location. Options can be provided to
+// ignore volatile fields.
+func (i Installation) Equal(other Installation, opts ...EqualOption) bool {
+	var eo equalOptions
+	for _, o := range opts {
+		o(&eo)
+	}
+
+	a, b := i.normalize(eo), other.normalize(eo)
+	return reflect.DeepEqual(a, b)
+}
+
+func (i Installation) normalize(eo equalOptions) Installation {
+	i.InstallDate = i.InstallDate.UTC().Round(0)
+	i.UpdateDate = i.UpdateDate.UTC().Round(0)
+	if eo.ignoreUpdateDate {
+		i.UpdateDate = time.Time{}
+	}
+	if eo.ignoreState {
+		i.State = 0
+		i.IsRebootRequired = false
+	}
+	return i
+}
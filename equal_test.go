@@ -0,0 +1,29 @@
+package vswhere
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallationEqual(t *testing.T) {
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	a := Installation{InstanceID: "abc", InstallDate: date, UpdateDate: date, State: 1}
+	b := a
+	b.InstallDate = date.In(time.FixedZone("PST", -8*60*60))
+	require.True(t, a.Equal(b))
+
+	b.UpdateDate = date.Add(time.Hour)
+	require.False(t, a.Equal(b))
+	require.True(t, a.Equal(b, IgnoreUpdateDate()))
+
+	b.State = 2
+	b.IsRebootRequired = true
+	require.False(t, a.Equal(b, IgnoreUpdateDate()))
+	require.True(t, a.Equal(b, IgnoreUpdateDate(), IgnoreState()))
+
+	b.InstanceID = "def"
+	require.False(t, a.Equal(b, IgnoreUpdateDate(), IgnoreState()))
+}
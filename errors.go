@@ -0,0 +1,97 @@
+package vswhere
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by this package, which may be wrapped with more detail.
+// Use errors.Is to check for them.
+var (
+	// ErrVswhereNotFound is returned when vswhere isn't installed and no
+	// other backend could answer the query.
+	ErrVswhereNotFound = fmt.Errorf("%w: vswhere not found", errBackendUnavailable)
+
+	// ErrNoInstallations is returned by functions which select a single
+	// installation, such as FindMSBuild, when none meet the criteria.
+	ErrNoInstallations = errors.New("no installation found")
+
+	// ErrInstallationNotFound is returned when a specific installation, such
+	// as the one at the path given to Get, doesn't exist.
+	ErrInstallationNotFound = errors.New("installation not found")
+
+	// ErrInvalidVersionRange is returned when a version range given to
+	// WithVersion or Requirements can't be parsed.
+	ErrInvalidVersionRange = errors.New("invalid version range")
+
+	// ErrInvalidArgument is returned when options are invalid or
+	// contradictory, or when vswhere rejects its arguments, such as an
+	// unknown flag or a malformed value.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrAccessDenied is returned when vswhere can't read the installer's
+	// state due to permissions.
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// exitCodeErrors maps the Win32 error codes vswhere exits with to errors.
+// vswhere exits with either the Win32 code or the HRESULT wrapping it.
+var exitCodeErrors = map[int]error{
+	0x5:   ErrAccessDenied,         // ERROR_ACCESS_DENIED, E_ACCESSDENIED
+	0x57:  ErrInvalidArgument,      // ERROR_INVALID_PARAMETER, E_INVALIDARG
+	0x490: ErrInstallationNotFound, // ERROR_NOT_FOUND, E_NOTFOUND
+}
+
+// exitCodeError returns the error for a vswhere exit code, or nil if the
+// exit code has no corresponding error.
+func exitCodeError(code int) error {
+	// Unwrap HRESULT_FROM_WIN32.
+	if uint32(code)&0xFFFF0000 == 0x80070000 {
+		code = int(uint32(code) & 0xFFFF)
+	}
+	return exitCodeErrors[code]
+}
+
+// ExecError is returned when vswhere fails to run or exits with an error.
+type ExecError struct {
+	// Path is the vswhere executable that was run. It is empty if vswhere
+	// was run by a custom Runner.
+	Path string
+
+	// Args are the arguments passed to vswhere.
+	Args []string
+
+	// ExitCode is the exit code of vswhere, or -1 if it failed to start or
+	// was killed.
+	ExitCode int
+
+	// Stderr is the output vswhere wrote to stderr.
+	Stderr string
+
+	// Err is the error returned when running vswhere.
+	Err error
+}
+
+// Error returns the message vswhere wrote to stderr, or the underlying error
+// if vswhere wrote nothing.
+func (e *ExecError) Error() string {
+	msg := strings.TrimSpace(e.Stderr)
+	if msg == "" {
+		msg = e.Err.Error()
+	}
+	if e.ExitCode < 0 {
+		return "vswhere failed: " + msg
+	}
+	return fmt.Sprintf("vswhere failed with exit code %d: %s", e.ExitCode, msg)
+}
+
+// Unwrap returns the underlying error.
+func (e *ExecError) Unwrap() error { return e.Err }
+
+// Is reports whether the exit code of vswhere corresponds to target, such as
+// ErrInvalidArgument.
+func (e *ExecError) Is(target error) bool {
+	err := exitCodeError(e.ExitCode)
+	return err != nil && err == target
+}
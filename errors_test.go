@@ -0,0 +1,63 @@
+package vswhere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	setenv(t, "VSWHERE_PATH", "")
+	setenv(t, "ProgramFiles(x86)", t.TempDir())
+	_, err := NewFinder(WithBackends(BackendVswhere)).Find(context.Background())
+	require.True(t, errors.Is(err, ErrVswhereNotFound), "%v", err)
+
+	f := NewFinder(WithFinderRunner(&fakeRunner{stdout: "[]"}))
+	_, err = f.Get(context.Background(), `C:\VS`)
+	require.True(t, errors.Is(err, ErrInstallationNotFound), "%v", err)
+
+	_, _, err = explain(nil, nil, Requirements{})
+	require.True(t, errors.Is(err, ErrNoInstallations), "%v", err)
+
+	_, err = inVersionRange("16.0", "[16.0")
+	require.True(t, errors.Is(err, ErrInvalidVersionRange), "%v", err)
+}
+
+type errRunner struct{ err error }
+
+func (r errRunner) Run(ctx context.Context, args []string) ([]byte, []byte, error) {
+	return nil, []byte("Error 0x57: unknown argument\r\n"), r.err
+}
+
+func TestExecError(t *testing.T) {
+	runErr := errors.New("connection reset")
+	_, err := NewFinder(WithFinderRunner(errRunner{err: runErr})).Find(context.Background(), WithLatest(true))
+
+	var execErr *ExecError
+	require.True(t, errors.As(err, &execErr))
+	require.Equal(t, []string{"-latest", "-format", "json"}, execErr.Args)
+	require.Equal(t, -1, execErr.ExitCode)
+	require.Equal(t, "Error 0x57: unknown argument\r\n", execErr.Stderr)
+	require.True(t, errors.Is(err, runErr))
+	require.EqualError(t, err, "vswhere failed: Error 0x57: unknown argument")
+}
+
+func TestExitCodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code   uint32
+		expect error
+	}{
+		{0x57, ErrInvalidArgument},
+		{0x80070057, ErrInvalidArgument},
+		{0x80070490, ErrInstallationNotFound},
+		{5, ErrAccessDenied},
+		{1, nil},
+	} {
+		err := &ExecError{ExitCode: int(tc.code), Err: errors.New("exit status")}
+		for _, target := range []error{ErrInvalidArgument, ErrInstallationNotFound, ErrAccessDenied} {
+			require.Equal(t, target == tc.expect, errors.Is(err, target), "%#x is %v", tc.code, target)
+		}
+	}
+}
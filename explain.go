@@ -0,0 +1,182 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// Requirements describe the installation to select with ExplainSelect.
+type Requirements struct {
+	// Version is the range of installation versions to accept, using the same
+	// syntax as WithVersion (e.g., "[16.0,17.0)"), such as from
+	// VersionRange.String. Empty accepts any version.
+	Version string
+
+	// Products are the product IDs to accept. Empty accepts any product.
+	Products []string
+
+	// Components are the component or workload IDs that must all be
+	// installed.
+	Components []string
+
+	// Prerelease accepts prerelease installations.
+	Prerelease bool
+
+	// Incomplete accepts installations which are incomplete or may not
+	// launch.
+	Incomplete bool
+}
+
+// Rejection is an installation that ExplainSelect didn't select, along with
+// the reasons why.
+type Rejection struct {
+	Installation Installation
+	Reasons      []string
+}
+
+// Explanation describes how ExplainSelect made its decision.
+type Explanation struct {
+	// Candidates are the installations that met the requirements, newest
+	// first. The first candidate is the one selected.
+	Candidates []Installation
+
+	// Rejected are the installations that didn't meet the requirements.
+	Rejected []Rejection
+}
+
+// String returns a human-readable description of the decision, one
+// installation per line.
+func (e Explanation) String() string {
+	var sb strings.Builder
+	for i, c := range e.Candidates {
+		if i == 0 {
+			fmt.Fprintf(&sb, "selected %s (%s)\n", c.InstanceID, c.InstallationVersion)
+		} else {
+			fmt.Fprintf(&sb, "accepted %s (%s): older than selected\n", c.InstanceID, c.InstallationVersion)
+		}
+	}
+	for _, r := range e.Rejected {
+		fmt.Fprintf(&sb, "rejected %s (%s): %s\n", r.Installation.InstanceID, r.Installation.InstallationVersion, strings.Join(r.Reasons, "; "))
+	}
+	return sb.String()
+}
+
+// ExplainSelect selects the newest installation meeting req and explains why
+// every other installation was or wasn't selected. Options can be provided to
+// customize how vswhere is run (e.g., WithExecutable); selection should be
+// described through req instead.
+//
+// ExplainSelect returns an error alongside the Explanation if no installation
+// meets req.
+func ExplainSelect(ctx context.Context, req Requirements, options ...Option) (Installation, Explanation, error) {
+	return Default().ExplainSelect(ctx, req, options...)
+}
+
+// ExplainSelect selects the newest installation meeting req using the Finder
+// and explains why.
+func (f *Finder) ExplainSelect(ctx context.Context, req Requirements, options ...Option) (Installation, Explanation, error) {
+	searchOpts := append(options, WithAll(true), WithPrerelease(true), WithProducts([]string{"*"}), WithIncludePackages(true))
+	installs, err := f.Find(ctx, searchOpts...)
+	if err != nil {
+		return Installation{}, Explanation{}, err
+	}
+	return explain(installs, componentsByInstance(installs, req.Components), req)
+}
+
+// componentsByInstance returns which of the component IDs are among the
+// packages of each installation, keyed by instance ID.
+func componentsByInstance(installs []Installation, ids []string) map[string][]string {
+	res := make(map[string][]string, len(installs))
+	for _, install := range installs {
+		res[install.InstanceID] = installedPackages(install, ids)
+	}
+	return res
+}
+
+func explain(installs []Installation, components map[string][]string, req Requirements) (Installation, Explanation, error) {
+	var ex Explanation
+	for _, install := range installs {
+		reasons, err := rejectReasons(install, components[install.InstanceID], req)
+		if err != nil {
+			return Installation{}, Explanation{}, err
+		}
+		if len(reasons) > 0 {
+			ex.Rejected = append(ex.Rejected, Rejection{Installation: install, Reasons: reasons})
+			continue
+		}
+
+		// Keep candidates sorted newest first.
+		i := 0
+		for i < len(ex.Candidates) && version.Compare(ex.Candidates[i].InstallationVersion, install.InstallationVersion) >= 0 {
+			i++
+		}
+		ex.Candidates = append(ex.Candidates, Installation{})
+		copy(ex.Candidates[i+1:], ex.Candidates[i:])
+		ex.Candidates[i] = install
+	}
+
+	if len(ex.Candidates) == 0 {
+		return Installation{}, ex, fmt.Errorf("%w: none meet the requirements (%d rejected)", ErrNoInstallations, len(ex.Rejected))
+	}
+	return ex.Candidates[0], ex, nil
+}
+
+func rejectReasons(install Installation, installed []string, req Requirements) ([]string, error) {
+	var reasons []string
+	if len(req.Products) > 0 && !containsFold(req.Products, install.ProductID) {
+		reasons = append(reasons, fmt.Sprintf("product %s not requested", install.ProductID))
+	}
+	if req.Version != "" {
+		ok, err := inVersionRange(install.InstallationVersion, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("version %s out of range %s", install.InstallationVersion, req.Version))
+		}
+	}
+	if install.IsPrerelease && !req.Prerelease {
+		reasons = append(reasons, "prerelease excluded")
+	}
+	if !req.Incomplete {
+		if !install.IsComplete {
+			reasons = append(reasons, "incomplete")
+		}
+		if !install.IsLaunchable {
+			reasons = append(reasons, "not launchable")
+		}
+	}
+
+	var missing []string
+	for _, id := range req.Components {
+		if !containsFold(installed, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		reasons = append(reasons, "missing components "+strings.Join(missing, ", "))
+	}
+	return reasons, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// inVersionRange reports whether v is within the vswhere version range r. A
+// bare version is treated as a minimum version.
+func inVersionRange(v, r string) (bool, error) {
+	vr, err := ParseVersionRange(r)
+	if err != nil {
+		return false, err
+	}
+	return vr.Contains(v), nil
+}
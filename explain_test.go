@@ -0,0 +1,93 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	installs := []Installation{
+		{InstanceID: "vs2017", InstallationVersion: "15.9.28307.1000", ProductID: "Microsoft.VisualStudio.Product.Community", IsComplete: true, IsLaunchable: true},
+		{InstanceID: "vs2019", InstallationVersion: "16.11.34114.132", ProductID: "Microsoft.VisualStudio.Product.Community", IsComplete: true, IsLaunchable: true},
+		{InstanceID: "vs2019bt", InstallationVersion: "16.11.34114.132", ProductID: "Microsoft.VisualStudio.Product.BuildTools", IsComplete: true, IsLaunchable: true},
+		{InstanceID: "vs2019old", InstallationVersion: "16.4.29709.97", ProductID: "Microsoft.VisualStudio.Product.Community", IsComplete: true, IsLaunchable: true},
+		{InstanceID: "vs2022pre", InstallationVersion: "17.9.34321.82", ProductID: "Microsoft.VisualStudio.Product.Community", IsComplete: true, IsLaunchable: true, IsPrerelease: true},
+		{InstanceID: "vs2022", InstallationVersion: "17.8.34322.80", ProductID: "Microsoft.VisualStudio.Product.Community", IsLaunchable: true},
+	}
+	vc := "Microsoft.VisualStudio.Component.VC.Tools.x86.x64"
+	components := map[string][]string{
+		"vs2017":    {vc},
+		"vs2019":    {vc},
+		"vs2019old": {vc},
+		"vs2022pre": {vc},
+		"vs2022":    {vc},
+	}
+
+	install, ex, err := explain(installs, components, Requirements{
+		Version:    "[16.0,18.0)",
+		Products:   []string{"Microsoft.VisualStudio.Product.Community"},
+		Components: []string{vc},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "vs2019", install.InstanceID)
+	require.Len(t, ex.Candidates, 2)
+	require.Equal(t, "vs2019old", ex.Candidates[1].InstanceID)
+
+	reasons := make(map[string][]string)
+	for _, r := range ex.Rejected {
+		reasons[r.Installation.InstanceID] = r.Reasons
+	}
+	require.Equal(t, map[string][]string{
+		"vs2017": {"version 15.9.28307.1000 out of range [16.0,18.0)"},
+		"vs2019bt": {
+			"product Microsoft.VisualStudio.Product.BuildTools not requested",
+			"missing components " + vc,
+		},
+		"vs2022pre": {"prerelease excluded"},
+		"vs2022":    {"incomplete"},
+	}, reasons)
+
+	_, ex, err = explain(installs, components, Requirements{Version: "18.0"})
+	require.Error(t, err)
+	require.Len(t, ex.Rejected, len(installs))
+}
+
+func TestInVersionRange(t *testing.T) {
+	for _, tc := range []struct {
+		v, r   string
+		expect bool
+	}{
+		{"16.11.1", "16.0", true},
+		{"15.9.1", "16.0", false},
+		{"16.0", "[16.0,17.0)", true},
+		{"17.0", "[16.0,17.0)", false},
+		{"17.0", "[16.0,17.0]", true},
+		{"16.0", "(16.0,)", false},
+		{"99.0", "[16.0,)", true},
+	} {
+		ok, err := inVersionRange(tc.v, tc.r)
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, ok, "%s in %s", tc.v, tc.r)
+	}
+
+	_, err := inVersionRange("16.0", "[16.0")
+	require.Error(t, err)
+}
+
+func TestFinderExplainSelect(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "installationVersion": "17.8.34322.80", "isComplete": true, "isLaunchable": true,
+   "packages": [{"id": "Microsoft.VisualStudio.Component.VC.Tools.x86.x64"}]},
+  {"instanceId": "b", "installationVersion": "17.9.34607.119", "isComplete": true, "isLaunchable": true}
+]`}
+
+	install, ex, err := NewFinder(WithFinderRunner(r)).ExplainSelect(context.Background(), Requirements{
+		Components: []string{"Microsoft.VisualStudio.Component.VC.Tools.x86.x64"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a", install.InstanceID)
+	require.Len(t, ex.Rejected, 1)
+	require.Contains(t, r.args, "packages")
+}
@@ -0,0 +1,156 @@
+package vswhere
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// ParseFilter parses a filter expression into Requirements. An expression is
+// one or more terms joined by "&&":
+//
+//	product==BuildTools            accept a product; list several with commas
+//	version>=17                    compare the installation version using
+//	                               ==, >=, >, <=, or <
+//	has(VC.Tools.x86.x64)          require a component or workload
+//	prerelease                     accept prerelease installations
+//	incomplete                     accept incomplete installations
+//
+// A partial version compared with == matches every version it prefixes, so
+// version==17 accepts any 17.x installation.
+//
+// Product IDs without a "Microsoft." prefix are expanded with
+// "Microsoft.VisualStudio.Product.". Component IDs are expanded with
+// "Microsoft.VisualStudio." when they start with "Workload.",
+// "ComponentGroup.", or "Component.", and with
+// "Microsoft.VisualStudio.Component." otherwise. For example:
+//
+//	product==BuildTools && version>=17 && has(VC.Tools.x86.x64)
+func ParseFilter(expr string) (Requirements, error) {
+	var (
+		req      Requirements
+		min, max string
+		minIncl  bool
+		maxIncl  bool
+	)
+	narrowMin := func(v string, incl bool) {
+		if c := version.Compare(v, min); min == "" || c > 0 || (c == 0 && !incl) {
+			min, minIncl = v, incl
+		}
+	}
+	narrowMax := func(v string, incl bool) {
+		if c := version.Compare(v, max); max == "" || c < 0 || (c == 0 && !incl) {
+			max, maxIncl = v, incl
+		}
+	}
+	for _, term := range strings.Split(expr, "&&") {
+		term = strings.TrimSpace(term)
+		switch {
+		case term == "":
+			return Requirements{}, fmt.Errorf("empty term in filter %q", expr)
+		case term == "prerelease":
+			req.Prerelease = true
+		case term == "incomplete":
+			req.Incomplete = true
+
+		case strings.HasPrefix(term, "has(") && strings.HasSuffix(term, ")"):
+			id := strings.TrimSpace(term[len("has(") : len(term)-1])
+			if id == "" {
+				return Requirements{}, fmt.Errorf("missing component in %q", term)
+			}
+			req.Components = append(req.Components, expandComponentID(id))
+
+		case strings.HasPrefix(term, "product"):
+			value, ok := cutOperator(strings.TrimPrefix(term, "product"), "==")
+			if !ok {
+				return Requirements{}, fmt.Errorf("invalid product term %q: only == is supported", term)
+			}
+			for _, id := range strings.Split(value, ",") {
+				if id = strings.TrimSpace(id); id == "" {
+					return Requirements{}, fmt.Errorf("missing product in %q", term)
+				}
+				req.Products = append(req.Products, expandID(id, "Microsoft.VisualStudio.Product."))
+			}
+
+		case strings.HasPrefix(term, "version"):
+			rest := strings.TrimSpace(strings.TrimPrefix(term, "version"))
+			var op string
+			for _, o := range []string{"==", ">=", "<=", ">", "<"} {
+				if strings.HasPrefix(rest, o) {
+					op = o
+					break
+				}
+			}
+			v := strings.TrimSpace(strings.TrimPrefix(rest, op))
+			if op == "" || v == "" {
+				return Requirements{}, fmt.Errorf("invalid version term %q", term)
+			}
+
+			// A partial version such as 17 or 17.8 matches every version it
+			// prefixes, so == covers up to the next value of its last part.
+			if op == "==" && strings.Count(v, ".") < 3 {
+				next, err := nextVersion(v)
+				if err != nil {
+					return Requirements{}, fmt.Errorf("invalid version term %q: %w", term, err)
+				}
+				narrowMin(v, true)
+				narrowMax(next, false)
+				break
+			}
+
+			// Narrow the range with each comparison.
+			if op != "<" && op != "<=" {
+				narrowMin(v, op != ">")
+			}
+			if op != ">" && op != ">=" {
+				narrowMax(v, op != "<")
+			}
+
+		default:
+			return Requirements{}, fmt.Errorf("unknown term %q", term)
+		}
+	}
+
+	req.Version = VersionRange{Min: min, Max: max, IncludeMin: minIncl, IncludeMax: maxIncl}.String()
+	return req, nil
+}
+
+// cutOperator returns the value following op in s.
+func cutOperator(s, op string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, op) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(s, op)), true
+}
+
+// expandComponentID qualifies a component or workload ID given to has().
+func expandComponentID(id string) string {
+	for _, kind := range []string{"Workload.", "ComponentGroup.", "Component."} {
+		if strings.HasPrefix(id, kind) {
+			return "Microsoft.VisualStudio." + id
+		}
+	}
+	return expandID(id, "Microsoft.VisualStudio.Component.")
+}
+
+// nextVersion increments the last part of the partial version v, returning
+// the first version it doesn't prefix.
+func nextVersion(v string) (string, error) {
+	i := strings.LastIndex(v, ".") + 1
+	n, err := strconv.Atoi(v[i:])
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("invalid version %q", v)
+	}
+	return v[:i] + strconv.Itoa(n+1), nil
+}
+
+// expandID prefixes id with prefix unless it is already fully qualified.
+func expandID(id, prefix string) string {
+	if strings.HasPrefix(id, "Microsoft.") {
+		return id
+	}
+	return prefix + id
+}
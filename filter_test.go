@@ -0,0 +1,84 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	for _, tc := range []struct {
+		expr   string
+		expect Requirements
+	}{
+		{
+			expr: "product==BuildTools && version>=17 && has(VC.Tools.x86.x64)",
+			expect: Requirements{
+				Version:    "[17,)",
+				Products:   []string{"Microsoft.VisualStudio.Product.BuildTools"},
+				Components: []string{"Microsoft.VisualStudio.Component.VC.Tools.x86.x64"},
+			},
+		},
+		{
+			expr: "product == Community,Microsoft.VisualStudio.Product.Enterprise",
+			expect: Requirements{
+				Products: []string{"Microsoft.VisualStudio.Product.Community", "Microsoft.VisualStudio.Product.Enterprise"},
+			},
+		},
+		{
+			expr:   "version>=16 && version<18 && version>16.4",
+			expect: Requirements{Version: "(16.4,18)"},
+		},
+		{
+			expr:   "version==17.8",
+			expect: Requirements{Version: "[17.8,17.9)"},
+		},
+		{
+			expr:   "version==17",
+			expect: Requirements{Version: "[17,18)"},
+		},
+		{
+			expr:   "version==17.8.34330.188",
+			expect: Requirements{Version: "[17.8.34330.188,17.8.34330.188]"},
+		},
+		{
+			expr:   "version==17 && version<17.4",
+			expect: Requirements{Version: "[17,17.4)"},
+		},
+		{
+			expr: "has(Workload.NativeDesktop) && has(ComponentGroup.WindowsAppSDK.Cpp) && has(Component.MSBuild)",
+			expect: Requirements{
+				Components: []string{
+					"Microsoft.VisualStudio.Workload.NativeDesktop",
+					"Microsoft.VisualStudio.ComponentGroup.WindowsAppSDK.Cpp",
+					"Microsoft.VisualStudio.Component.MSBuild",
+				},
+			},
+		},
+		{
+			expr: "has(Microsoft.VisualStudio.Workload.NativeDesktop) && prerelease && incomplete",
+			expect: Requirements{
+				Components: []string{"Microsoft.VisualStudio.Workload.NativeDesktop"},
+				Prerelease: true,
+				Incomplete: true,
+			},
+		},
+	} {
+		req, err := ParseFilter(tc.expr)
+		require.NoError(t, err, tc.expr)
+		require.Equal(t, tc.expect, req, tc.expr)
+	}
+
+	for _, expr := range []string{
+		"",
+		"version>=17 &&",
+		"product!=BuildTools",
+		"version~17",
+		"has()",
+		"version==17.x",
+		"latest",
+	} {
+		_, err := ParseFilter(expr)
+		require.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,161 @@
+package vswhere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindFiles finds files beneath matching installations using vswhere's -find
+// flag, returning their full paths. pattern is relative to the installation
+// path and may contain "*" and "?" wildcards within path segments and "**" to
+// match any number of directories (e.g., `MSBuild\**\Bin\MSBuild.exe`).
+// vswhere 2.6.2 or newer is required.
+func FindFiles(ctx context.Context, pattern string, options ...Option) ([]string, error) {
+	return Default().FindFiles(ctx, pattern, options...)
+}
+
+// FindFiles finds files beneath matching installations, returning their full
+// paths. If vswhere isn't the Finder's first backend or isn't installed, the
+// installations found by the next available backend are searched instead.
+func (f *Finder) FindFiles(ctx context.Context, pattern string, options ...Option) ([]string, error) {
+	searchOpts, err := f.newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	args, err := searchOpts.args()
+	if err != nil {
+		return nil, err
+	}
+
+	if values, ok, err := f.runValues(ctx, &searchOpts, args, "-find", pattern); ok {
+		return values, err
+	}
+
+	installs, err := f.run(ctx, &searchOpts, args)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, install := range installs {
+		matches, err := findFiles(ctx, install.InstallationPath, pattern)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// findFiles returns the files beneath root matching pattern. The walk starts
+// at the directory named by the pattern's leading segments without wildcards
+// and skips directories which can't contain a match.
+func findFiles(ctx context.Context, root, pattern string) ([]string, error) {
+	patternSegs := splitPath(pattern)
+	start := literalPrefix(root, patternSegs)
+
+	var matches []string
+	err := filepath.Walk(start, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) || os.IsPermission(err) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if !mayContainMatch(patternSegs, splitPath(rel)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if matchSegments(patternSegs, splitPath(rel)) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// literalPrefix returns the path beneath root named by the leading pattern
+// segments without wildcards. Segments are matched ignoring case, using the
+// name found on disk, and the path stops at the first segment not found.
+func literalPrefix(root string, pattern []string) string {
+	dir := root
+	if len(pattern) == 0 {
+		return dir
+	}
+	for _, seg := range pattern[:len(pattern)-1] {
+		if strings.ContainsAny(seg, "*?[") {
+			break
+		}
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			break
+		}
+		var next string
+		for _, e := range entries {
+			if e.IsDir() && strings.EqualFold(e.Name(), seg) {
+				next = filepath.Join(dir, e.Name())
+				break
+			}
+		}
+		if next == "" {
+			break
+		}
+		dir = next
+	}
+	return dir
+}
+
+func splitPath(p string) []string {
+	return strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' })
+}
+
+// matchSegments reports whether the path segments match the pattern segments,
+// ignoring case. "**" matches zero or more segments.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, err := filepath.Match(strings.ToLower(pattern[0]), strings.ToLower(path[0]))
+	return err == nil && ok && matchSegments(pattern[1:], path[1:])
+}
+
+// mayContainMatch reports whether a file beneath the directory with the path
+// segments dir could match the pattern segments.
+func mayContainMatch(pattern, dir []string) bool {
+	for i, seg := range dir {
+		if i >= len(pattern) {
+			return false
+		}
+		if pattern[i] == "**" {
+			return true
+		}
+		ok, err := filepath.Match(strings.ToLower(pattern[i]), strings.ToLower(seg))
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return len(dir) < len(pattern)
+}
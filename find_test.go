@@ -0,0 +1,79 @@
+package vswhere
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFiles(t *testing.T) {
+	r := &fakeRunner{stdout: "C:\\VS\\MSBuild\\Current\\Bin\\MSBuild.exe\r\n"}
+	paths, err := NewFinder(WithFinderRunner(r)).FindFiles(context.Background(), `MSBuild\**\Bin\MSBuild.exe`, WithLatest(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-latest", "-find", `MSBuild\**\Bin\MSBuild.exe`, "-format", "value"}, r.args)
+	require.Equal(t, []string{`C:\VS\MSBuild\Current\Bin\MSBuild.exe`}, paths)
+
+	// Other backends search the installation directory.
+	vs := t.TempDir()
+	for _, name := range []string{
+		"MSBuild/Current/Bin/MSBuild.exe",
+		"MSBuild/Current/Bin/amd64/MSBuild.exe",
+		"MSBuild/Current/Bin/MSBuild.dll",
+	} {
+		path := filepath.Join(vs, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	}
+	dir := t.TempDir()
+	state, err := json.Marshal(map[string]interface{}{
+		"installationPath": vs,
+		"product":          map[string]string{"id": "Microsoft.VisualStudio.Product.Community"},
+	})
+	require.NoError(t, err)
+	writeState(t, dir, string(state), "abc")
+
+	paths, err = NewFinder(WithFinderOffline(dir)).FindFiles(context.Background(), `msbuild\**\msbuild.exe`)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(vs, "MSBuild", "Current", "Bin", "MSBuild.exe"),
+		filepath.Join(vs, "MSBuild", "Current", "Bin", "amd64", "MSBuild.exe"),
+	}, paths)
+}
+
+func TestMatchSegments(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, path string
+		expect        bool
+	}{
+		{`**\MSBuild.exe`, `MSBuild\Current\Bin\MSBuild.exe`, true},
+		{`MSBuild\**\Bin\MSBuild.exe`, `MSBuild\Current\Bin\MSBuild.exe`, true},
+		{`Common7\IDE\devenv.exe`, `common7\ide\DEVENV.EXE`, true},
+		{`VC\Tools\MSVC\*\bin\Hostx64\x64\cl.exe`, `VC\Tools\MSVC\14.38.33130\bin\Hostx64\x64\cl.exe`, true},
+		{`VC\*\cl.exe`, `VC\Tools\cl.exe\x`, false},
+		{`*.exe`, `Common7\devenv.exe`, false},
+	} {
+		require.Equal(t, tc.expect, matchSegments(splitPath(tc.pattern), splitPath(tc.path)), "%s ~ %s", tc.pattern, tc.path)
+	}
+}
+
+func TestMayContainMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, dir string
+		expect       bool
+	}{
+		{`MSBuild\**\Bin\MSBuild.exe`, `MSBuild`, true},
+		{`MSBuild\**\Bin\MSBuild.exe`, `msbuild\Current\Binmd64`, true},
+		{`MSBuild\**\Bin\MSBuild.exe`, `Common7`, false},
+		{`VC\Tools\MSVC\*\bin\Hostx64\x64\cl.exe`, `VC\Tools\MSVC\14.38.33130\bin`, true},
+		{`VC\Tools\MSVC\*\bin\Hostx64\x64\cl.exe`, `VC\Tools\Llvm`, false},
+		{`Common7\IDE\devenv.exe`, `Common7\IDE`, true},
+		{`Common7\IDE\devenv.exe`, `Common7\IDE\devenv.exe`, false},
+	} {
+		require.Equal(t, tc.expect, mayContainMatch(splitPath(tc.pattern), splitPath(tc.dir)), "%s ~ %s", tc.pattern, tc.dir)
+	}
+}
@@ -0,0 +1,380 @@
+package vswhere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Finder finds installations using its own configuration. The package-level
+// functions, such as Find and Get, use the Finder returned by Default.
+type Finder struct {
+	executable   string
+	env          []string
+	timeout      time.Duration
+	logger       *log.Logger
+	runner       Runner
+	backends     []Backend
+	stateDir     string
+	imageRoot    string
+	transformers []Transformer
+	config       func() (Config, error)
+	policy       bool
+}
+
+// Runner runs vswhere with the given arguments, returning its stdout and
+// stderr. A Runner can be given to a Finder to replace how vswhere is
+// executed, such as to fake vswhere in tests or to run it remotely. If the
+// error is an *exec.ExitError, stderr is used to describe the failure.
+type Runner interface {
+	Run(ctx context.Context, args []string) (stdout, stderr []byte, err error)
+}
+
+// execRunner runs vswhere as a local process.
+type execRunner struct {
+	path string
+	env  []string
+}
+
+func (r execRunner) Run(ctx context.Context, args []string) ([]byte, []byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.path, args...)
+	cmd.Env = r.env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// FinderOption configures a Finder.
+type FinderOption func(f *Finder)
+
+// WithFinderExecutable uses the vswhere.exe at path for every query made by
+// the Finder. WithExecutable takes precedence for individual queries.
+func WithFinderExecutable(path string) FinderOption {
+	return func(f *Finder) { f.executable = path }
+}
+
+// WithFinderEnv runs vswhere with the environment env, in the form used by
+// exec.Cmd. Defaults to the environment of the current process.
+func WithFinderEnv(env []string) FinderOption {
+	return func(f *Finder) { f.env = env }
+}
+
+// WithFinderTimeout cancels every query that takes longer than d.
+func WithFinderTimeout(d time.Duration) FinderOption {
+	return func(f *Finder) { f.timeout = d }
+}
+
+// WithFinderLogger logs each vswhere invocation to l.
+func WithFinderLogger(l *log.Logger) FinderOption {
+	return func(f *Finder) { f.logger = l }
+}
+
+// WithFinderRunner runs vswhere through r. The executable, environment, and
+// Setup Configuration API fallback are ignored when a Runner is given.
+func WithFinderRunner(r Runner) FinderOption {
+	return func(f *Finder) { f.runner = r }
+}
+
+// WithFinderOffline reads installations from the Visual Studio Installer's
+// state files in dir instead of running vswhere; see ReadStateDir. If dir is
+// empty, the installer's default state directory is used. WithRequires isn't
+// supported when reading state files.
+func WithFinderOffline(dir string) FinderOption {
+	return func(f *Finder) { f.backends, f.stateDir = []Backend{BackendStateFiles}, dir }
+}
+
+// Transformer rewrites the installations found by a Finder, such as to map
+// paths into a container, redact fields, or add synthetic installations in
+// tests.
+type Transformer func([]Installation) []Installation
+
+// WithFinderTransformer applies t to the installations returned by Find and
+// Get. Transformers run in the order they were given.
+func WithFinderTransformer(t Transformer) FinderOption {
+	return func(f *Finder) { f.transformers = append(f.transformers, t) }
+}
+
+// WithFinderConfig applies the search options described by c to every query
+// made by the Finder. Options given to a query take precedence.
+func WithFinderConfig(c Config) FinderOption {
+	return func(f *Finder) { f.config = func() (Config, error) { return c, nil } }
+}
+
+// WithFinderExcludeIncomplete removes incomplete and unlaunchable
+// installations from the results of every query made by the Finder, even if
+// a backend, Runner, or WithExtraArgs reported them. Queries using WithAll
+// are unaffected. The installations removed are recorded in
+// RunInfo.Excluded.
+func WithFinderExcludeIncomplete(exclude bool) FinderOption {
+	return func(f *Finder) { f.policy = exclude }
+}
+
+// Exclusion describes an installation removed from the results by
+// WithFinderExcludeIncomplete.
+type Exclusion struct {
+	InstanceID string
+
+	// Reason is why the installation was removed: "incomplete" or "not
+	// launchable".
+	Reason string
+}
+
+// excludeIncomplete removes incomplete and unlaunchable installations,
+// returning the remaining installations and the ones removed.
+func excludeIncomplete(installs []Installation) ([]Installation, []Exclusion) {
+	var (
+		res      = installs[:0]
+		excluded []Exclusion
+	)
+	for _, install := range installs {
+		switch {
+		case !install.IsComplete:
+			excluded = append(excluded, Exclusion{InstanceID: install.InstanceID, Reason: "incomplete"})
+		case !install.IsLaunchable:
+			excluded = append(excluded, Exclusion{InstanceID: install.InstanceID, Reason: "not launchable"})
+		default:
+			res = append(res, install)
+		}
+	}
+	return res, excluded
+}
+
+// NewFinder creates a new Finder. Options can be provided to customize it.
+func NewFinder(opts ...FinderOption) *Finder {
+	var f Finder
+	for _, o := range opts {
+		o(&f)
+	}
+	return &f
+}
+
+var (
+	defaultMut    sync.RWMutex
+	defaultFinder = newDefaultFinder()
+)
+
+// newDefaultFinder creates the Finder returned by Default, which applies
+// DefaultConfig to every query so that it picks up changes to the
+// environment.
+func newDefaultFinder() *Finder {
+	f := NewFinder()
+	f.config = DefaultConfig
+	return f
+}
+
+// SetDefault replaces the Finder used by the package-level functions, such as
+// Find and Get, so an application can configure the executable, timeout,
+// logging, and other settings once instead of passing a Finder everywhere.
+// Passing nil restores a Finder with the default configuration, which applies
+// DefaultConfig. SetDefault is safe to call concurrently with queries; queries
+// already running keep using the previous Finder.
+func SetDefault(f *Finder) {
+	if f == nil {
+		f = newDefaultFinder()
+	}
+	defaultMut.Lock()
+	defer defaultMut.Unlock()
+	defaultFinder = f
+}
+
+// Default returns the Finder used by the package-level functions.
+func Default() *Finder {
+	defaultMut.RLock()
+	defer defaultMut.RUnlock()
+	return defaultFinder
+}
+
+// Find finds all installations. Options can be provided to customize the search
+// behavior. Installations are ordered newest version first, then by product ID
+// and instance ID, regardless of the backend which found them.
+func (f *Finder) Find(ctx context.Context, options ...Option) ([]Installation, error) {
+	searchOpts, err := f.newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	args, err := searchOpts.args()
+	if err != nil {
+		return nil, err
+	}
+	return f.run(ctx, &searchOpts, args)
+}
+
+// FindStream is like Find but delivers installations over a channel. The
+// installation channel is closed once all installations have been sent. At
+// most one error is sent to the error channel, which is closed after the
+// installation channel. If ctx is canceled before all installations are
+// received, ctx.Err() is sent to the error channel.
+func (f *Finder) FindStream(ctx context.Context, options ...Option) (<-chan Installation, <-chan error) {
+	var (
+		installCh = make(chan Installation)
+		errCh     = make(chan error, 1)
+	)
+
+	go func() {
+		defer close(errCh)
+		defer close(installCh)
+
+		installs, err := f.Find(ctx, options...)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		for _, install := range installs {
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case installCh <- install:
+			}
+		}
+	}()
+
+	return installCh, errCh
+}
+
+// Get returns an indivdiual installation within a path. Returns an error if the
+// installation wasn't found. Options that select installations (such as
+// WithProducts) are ignored, but others (such as WithExecutable) apply.
+func (f *Finder) Get(ctx context.Context, path string, options ...Option) (Installation, error) {
+	searchOpts, err := f.newSearchOptions(options)
+	if err != nil {
+		return Installation{}, err
+	}
+	searchOpts.path = path
+	args, err := searchOpts.args()
+	if err != nil {
+		return Installation{}, err
+	}
+	installs, err := f.run(ctx, &searchOpts, args)
+	if err != nil {
+		return Installation{}, err
+	}
+	if len(installs) == 0 {
+		return Installation{}, fmt.Errorf("%w at path %s", ErrInstallationNotFound, path)
+	}
+	return installs[0], nil
+}
+
+// RunRaw runs vswhere with args and returns its stdout without parsing it,
+// allowing flags which aren't modeled by this package to be used. The
+// Finder's executable, environment, timeout, and Runner apply, but its
+// backends don't: RunRaw fails if vswhere isn't installed. Output is
+// converted to UTF-8 like any other query.
+func (f *Finder) RunRaw(ctx context.Context, args ...string) ([]byte, error) {
+	var so searchOptions
+	return f.record(ctx, &so, args, func(ctx context.Context, info *RunInfo) ([]byte, error) {
+		return f.execVswhere(ctx, &so, args, info)
+	})
+}
+
+// newSearchOptions applies options after the Finder's config, if any.
+func (f *Finder) newSearchOptions(options []Option) (searchOptions, error) {
+	if f.config != nil {
+		c, err := f.config()
+		if err != nil {
+			return searchOptions{}, err
+		}
+		options = append(c.Options(), options...)
+	}
+	return newSearchOptions(options), nil
+}
+
+func (f *Finder) run(ctx context.Context, so *searchOptions, args []string) ([]Installation, error) {
+	out, err := f.runOutput(ctx, so, args)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+
+	var installs []Installation
+	if err := dec.Decode(&installs); err != nil {
+		return nil, fmt.Errorf("failed parsing output of vswhere: %w", err)
+	}
+	if len(so.required) > 0 {
+		installs = filterRequired(installs, so)
+	}
+	if f.policy && !so.all {
+		var excluded []Exclusion
+		installs, excluded = excludeIncomplete(installs)
+		for _, e := range excluded {
+			f.logf("excluded %s: %s", e.InstanceID, e.Reason)
+		}
+		if so.runInfo != nil {
+			so.runInfo.Excluded = excluded
+		}
+	}
+	if so.invariant {
+		for i := range installs {
+			if name := invariantName(installs[i]); name != "" {
+				installs[i].DisplayName = name
+			}
+		}
+	}
+	for _, t := range f.transformers {
+		installs = t(installs)
+	}
+	return installs, nil
+}
+
+// filterRequired removes installations missing any of the IDs given with
+// withRequired, which backends may match as OR when WithRequiresAny is given.
+// Packages are dropped again unless WithIncludePackages asked for them.
+func filterRequired(installs []Installation, so *searchOptions) []Installation {
+	res := installs[:0]
+	for _, install := range installs {
+		if len(installedPackages(install, so.required)) != len(so.required) {
+			continue
+		}
+		if !so.packages {
+			install.Packages = nil
+		}
+		res = append(res, install)
+	}
+	return res
+}
+
+// runOutput queries the Finder's backends and returns the installations found
+// as a JSON array, in the same format as vswhere.
+func (f *Finder) runOutput(ctx context.Context, so *searchOptions, args []string) ([]byte, error) {
+	return f.record(ctx, so, args, func(ctx context.Context, info *RunInfo) ([]byte, error) {
+		return f.query(ctx, so, args, info)
+	})
+}
+
+// record calls run with the Finder's timeout applied, storing the RunInfo it
+// fills in for WithRunInfo.
+func (f *Finder) record(ctx context.Context, so *searchOptions, args []string, run func(context.Context, *RunInfo) ([]byte, error)) ([]byte, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	info := RunInfo{
+		Args:        args,
+		Backend:     -1,
+		Unavailable: make(map[Backend]error),
+		Sources:     make(map[string]Backend),
+	}
+	out, err := run(ctx, &info)
+	info.Duration = time.Since(start)
+	if so.runInfo != nil {
+		*so.runInfo = info
+	}
+	return out, err
+}
+
+func (f *Finder) logf(format string, v ...interface{}) {
+	if f.logger != nil {
+		f.logger.Printf(format, v...)
+	}
+}
@@ -0,0 +1,241 @@
+package vswhere
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinderExecutable(t *testing.T) {
+	var logs bytes.Buffer
+	exe := filepath.Join(t.TempDir(), "vswhere.exe")
+	f := NewFinder(WithFinderExecutable(exe), WithFinderLogger(log.New(&logs, "", 0)))
+
+	var info RunInfo
+	_, err := f.Find(context.Background(), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, exe, info.Path)
+	require.Contains(t, logs.String(), "-format json")
+
+	override := filepath.Join(t.TempDir(), "vswhere.exe")
+	_, err = f.Get(context.Background(), `C:\VS`, WithExecutable(override), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, override, info.Path)
+	require.Equal(t, []string{"-path", `C:\VS`, "-format", "json"}, info.Args)
+}
+
+type fakeRunner struct {
+	args   []string
+	stdout string
+}
+
+func (r *fakeRunner) Run(ctx context.Context, args []string) ([]byte, []byte, error) {
+	r.args = args
+	return []byte(r.stdout), nil, nil
+}
+
+func TestFinderRunner(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "abc", "installationVersion": "17.8.34322.80"}]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	installs, err := f.Find(context.Background(), WithLatest(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-latest", "-format", "json"}, r.args)
+	require.Len(t, installs, 1)
+	require.Equal(t, "abc", installs[0].InstanceID)
+	require.Equal(t, "17.8.34322.80", installs[0].InstallationVersion)
+}
+
+func TestFinderTransformer(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "abc", "installationPath": "C:\\VS"}]`}
+	f := NewFinder(
+		WithFinderRunner(r),
+		WithFinderTransformer(func(installs []Installation) []Installation {
+			for i := range installs {
+				installs[i].InstallationPath = strings.Replace(installs[i].InstallationPath, `C:\`, `Z:\`, 1)
+			}
+			return installs
+		}),
+		WithFinderTransformer(func(installs []Installation) []Installation {
+			return append(installs, Installation{InstanceID: "synthetic"})
+		}),
+	)
+
+	installs, err := f.Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, installs, 2)
+	require.Equal(t, `Z:\VS`, installs[0].InstallationPath)
+	require.Equal(t, "synthetic", installs[1].InstanceID)
+
+	install, err := f.Get(context.Background(), `C:\VS`)
+	require.NoError(t, err)
+	require.Equal(t, `Z:\VS`, install.InstallationPath)
+}
+
+func TestFinderSort(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "vs2019", "installationVersion": "16.11.34114.132"},
+  {"instanceId": "vs2022", "installationVersion": "17.8.34322.80", "installDate": "2023-11-01T00:00:00Z"},
+  {"instanceId": "vs2022b", "installationVersion": "17.8.34322.80", "installDate": "2023-12-01T00:00:00Z"}
+]`}
+	f := NewFinder(WithFinderRunner(r))
+	ids := func(installs []Installation) []string {
+		var res []string
+		for _, install := range installs {
+			res = append(res, install.InstanceID)
+		}
+		return res
+	}
+
+	installs, err := f.Find(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"vs2022", "vs2022b", "vs2019"}, ids(installs))
+
+	installs, err = f.Find(context.Background(), WithSort(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-sort", "-format", "json"}, r.args)
+	require.Equal(t, []string{"vs2022b", "vs2022", "vs2019"}, ids(installs))
+}
+
+func TestFinderPackages(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "abc", "packages": [
+  {"id": "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", "version": "17.8.34129.139", "type": "Component"},
+  {"id": "Microsoft.VisualCpp.Tools.HostX64.TargetX64", "version": "17.8.33807.148", "chip": "x64", "language": "en-US", "type": "Vsix"}
+]}]`}
+	installs, err := NewFinder(WithFinderRunner(r)).Find(context.Background(), WithIncludePackages(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-include", "packages", "-format", "json"}, r.args)
+	require.Equal(t, []Package{
+		{ID: "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", Version: "17.8.34129.139", Type: "Component"},
+		{ID: "Microsoft.VisualCpp.Tools.HostX64.TargetX64", Version: "17.8.33807.148", Chip: "x64", Language: "en-US", Type: "Vsix"},
+	}, installs[0].Packages)
+}
+
+func TestFinderErrors(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "abc", "isComplete": false, "errors": {
+  "failedPackages": [{"id": "Microsoft.VisualCpp.Redist.14", "version": "14.38.33130", "chip": "x64", "type": "Exe", "logFilePath": "C:\\Temp\\dd_setup.log", "description": "Package failed", "signature": "sig", "details": "exit code 1603"}],
+  "skippedPackages": [{"id": "Microsoft.VisualCpp.Redist.14.Latest", "version": "14.38.33130", "type": "Exe"}]
+}}]`}
+	installs, err := NewFinder(WithFinderRunner(r)).Find(context.Background(), WithAll(true), WithIncludeErrors(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-all", "-include", "errors", "-format", "json"}, r.args)
+	require.Equal(t, Errors{
+		FailedPackages: []FailedPackage{{
+			ID:          "Microsoft.VisualCpp.Redist.14",
+			Version:     "14.38.33130",
+			Chip:        "x64",
+			Type:        "Exe",
+			LogFilePath: `C:\Temp\dd_setup.log`,
+			Description: "Package failed",
+			Signature:   "sig",
+			Details:     "exit code 1603",
+		}},
+		SkippedPackages: []Package{{ID: "Microsoft.VisualCpp.Redist.14.Latest", Version: "14.38.33130", Type: "Exe"}},
+	}, installs[0].Errors)
+}
+
+func TestFinderRunRaw(t *testing.T) {
+	r := &fakeRunner{stdout: "Visual Studio Locator version 3.1.7\n"}
+	out, err := NewFinder(WithFinderRunner(r)).RunRaw(context.Background(), "-nologo", "-?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"-nologo", "-?"}, r.args)
+	require.Equal(t, "Visual Studio Locator version 3.1.7\n", string(out))
+}
+
+func TestFinderInstallPath(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "installationPath": "C:\\VS\\2022\\Community"},
+  {"instanceId": "b", "installationPath": "C:\\VS\\2022\\Community2"}
+]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	installs, err := f.Find(context.Background(), WithInstallPath(`c:\vs\2022\community\Common7\IDE`), WithRequires([]string{"Microsoft.VisualStudio.Workload.NativeDesktop"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-requires", "Microsoft.VisualStudio.Workload.NativeDesktop", "-format", "json"}, r.args)
+	require.Len(t, installs, 1)
+	require.Equal(t, "a", installs[0].InstanceID)
+
+	_, err = f.Find(context.Background(), WithInstallPath(`C:\VS\2022\Community`), WithIncludePackages(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-path", `C:\VS\2022\Community`, "-include", "packages", "-format", "json"}, r.args)
+}
+
+func TestContainsPath(t *testing.T) {
+	require.True(t, containsPath(`C:\VS\Community`, `C:\VS\Community`))
+	require.True(t, containsPath(`C:\VS\Community\`, `c:/vs/community/Common7/IDE/devenv.exe`))
+	require.False(t, containsPath(`C:\VS\Community`, `C:\VS\Community2`))
+	require.False(t, containsPath("", `C:\VS`))
+}
+
+func TestFinderInvariantNames(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "productId": "Microsoft.VisualStudio.Product.BuildTools", "displayName": "Visual Studio Build Tools 2022 (Deutsch)",
+   "catalog": {"productName": "Visual Studio", "productLineVersion": "2022"}},
+  {"instanceId": "b", "productId": "Microsoft.VisualStudio.Product.Community", "displayName": "Visual Studio Community 2019"}
+]`}
+	installs, err := NewFinder(WithFinderRunner(r)).Find(context.Background(), WithInvariantNames(true))
+	require.NoError(t, err)
+	require.Equal(t, "Visual Studio Build Tools 2022", installs[0].DisplayName)
+	require.Equal(t, "Visual Studio Community 2019", installs[1].DisplayName)
+}
+
+func TestFinderExcludeIncomplete(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "isComplete": true, "isLaunchable": true},
+  {"instanceId": "b", "isComplete": false, "isLaunchable": true},
+  {"instanceId": "c", "isComplete": true, "isLaunchable": false}
+]`}
+	f := NewFinder(WithFinderRunner(r), WithFinderExcludeIncomplete(true))
+
+	var info RunInfo
+	installs, err := f.Find(context.Background(), WithRunInfo(&info))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Equal(t, "a", installs[0].InstanceID)
+	require.Equal(t, []Exclusion{
+		{InstanceID: "b", Reason: "incomplete"},
+		{InstanceID: "c", Reason: "not launchable"},
+	}, info.Excluded)
+
+	installs, err = f.Find(context.Background(), WithAll(true), WithRunInfo(&info))
+	require.NoError(t, err)
+	require.Len(t, installs, 3)
+	require.Empty(t, info.Excluded)
+}
+
+func TestSetDefault(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "a"}]`}
+	SetDefault(NewFinder(WithFinderRunner(r)))
+	t.Cleanup(func() { SetDefault(nil) })
+
+	installs, err := Find(context.Background(), WithLatest(true))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Equal(t, []string{"-latest", "-format", "json"}, r.args)
+
+	SetDefault(nil)
+	require.Nil(t, Default().runner)
+}
+
+func TestFinderHelpers(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "a", "installationVersion": "17.9.34607.119", "isComplete": true, "properties": {"nickname": "main"}}]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	installs, err := f.FindProduct(context.Background(), ProductTestAgent)
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+	require.Contains(t, r.args, ProductTestAgent)
+
+	status, err := f.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Installed, status)
+
+	install, err := f.GetByNickname(context.Background(), "main")
+	require.NoError(t, err)
+	require.Equal(t, "a", install.InstanceID)
+}
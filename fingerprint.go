@@ -0,0 +1,25 @@
+package vswhere
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+)
+
+// Fingerprint returns a stable identifier for an installation derived from its
+// product, channel, and installation path. Unlike the instance ID, the
+// fingerprint is unchanged when an installation is uninstalled and
+// reinstalled to the same location.
+func Fingerprint(install Installation) string {
+	h := sha256.New()
+	for _, s := range []string{
+		install.ProductID,
+		install.ChannelID,
+		strings.TrimRight(filepath.Clean(install.InstallationPath), `\/`),
+	} {
+		h.Write([]byte(strings.ToLower(s)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
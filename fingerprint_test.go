@@ -0,0 +1,29 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	install := Installation{
+		InstanceID:          "2a1b3c4d",
+		InstallationPath:    `C:\Program Files\Microsoft Visual Studio\2022\Community`,
+		InstallationVersion: "17.8.34322.80",
+		ProductID:           "Microsoft.VisualStudio.Product.Community",
+		ChannelID:           "VisualStudio.17.Release",
+	}
+	fp := Fingerprint(install)
+	require.Len(t, fp, 16)
+
+	reinstalled := install
+	reinstalled.InstanceID = "9f8e7d6c"
+	reinstalled.InstallationVersion = "17.9.34607.119"
+	reinstalled.InstallationPath = `C:\Program Files\Microsoft Visual Studio\2022\community\`
+	require.Equal(t, fp, Fingerprint(reinstalled))
+
+	preview := install
+	preview.ChannelID = "VisualStudio.17.Preview"
+	require.NotEqual(t, fp, Fingerprint(preview))
+}
@@ -0,0 +1,57 @@
+package vswhere
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// ErrUnsupportedFlag is returned when the installed vswhere is too old to
+// support a flag needed by a query.
+var ErrUnsupportedFlag = errors.New("unsupported vswhere flag")
+
+// flagVersions are the first versions of vswhere supporting each flag. Flags
+// which aren't listed are supported by every version of vswhere.
+var flagVersions = map[string]string{
+	"-prerelease":  "2.1",
+	"-requiresAny": "2.3",
+	"-path":        "2.5",
+	"-utf8":        "2.5.2",
+	"-find":        "2.6.2",
+	"-sort":        "2.7",
+	"-include":     "2.8",
+}
+
+// emulatedFlags are flags which are dropped when vswhere doesn't support
+// them, as the Finder applies them itself.
+var emulatedFlags = map[string]bool{
+	"-sort": true,
+}
+
+// supportsFlag reports whether vswhere version v is known to support flag.
+func supportsFlag(v, flag string) bool {
+	min, ok := flagVersions[flag]
+	return v != "" && (!ok || version.Compare(v, min) >= 0)
+}
+
+// gateFlags checks args against the flags supported by vswhere version v,
+// dropping emulated flags which aren't supported. If v is empty, the version
+// is unknown and args are returned unchanged.
+func gateFlags(v string, args []string) ([]string, error) {
+	if v == "" {
+		return args, nil
+	}
+
+	res := make([]string, 0, len(args))
+	for _, arg := range args {
+		if min, ok := flagVersions[arg]; ok && version.Compare(v, min) < 0 {
+			if emulatedFlags[arg] {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s requires vswhere %s or newer, found %s", ErrUnsupportedFlag, arg, min, v)
+		}
+		res = append(res, arg)
+	}
+	return res, nil
+}
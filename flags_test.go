@@ -0,0 +1,32 @@
+package vswhere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGateFlags(t *testing.T) {
+	args := []string{"-prerelease", "-sort", "-format", "json"}
+
+	res, err := gateFlags("", args)
+	require.NoError(t, err)
+	require.Equal(t, args, res)
+
+	res, err = gateFlags("3.1.7.0", args)
+	require.NoError(t, err)
+	require.Equal(t, args, res)
+
+	res, err = gateFlags("2.6.2.0", args)
+	require.NoError(t, err)
+	require.Equal(t, []string{"-prerelease", "-format", "json"}, res)
+
+	_, err = gateFlags("2.6.2.0", []string{"-include", "packages", "-format", "json"})
+	require.True(t, errors.Is(err, ErrUnsupportedFlag))
+	require.EqualError(t, err, "unsupported vswhere flag: -include requires vswhere 2.8 or newer, found 2.6.2.0")
+
+	require.True(t, supportsFlag("2.5.2", "-utf8"))
+	require.False(t, supportsFlag("2.4", "-utf8"))
+	require.False(t, supportsFlag("", "-utf8"))
+}
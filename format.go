@@ -0,0 +1,69 @@
+package vswhere
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// String returns a one-line summary of the installation.
+func (i Installation) String() string {
+	return fmt.Sprintf("%s %s (%s) at %s", i.DisplayName, i.InstallationVersion, i.InstanceID, i.InstallationPath)
+}
+
+var tableHeader = []string{"INSTANCE", "NAME", "VERSION", "PATH"}
+
+func tableRow(i Installation) []string {
+	return []string{i.InstanceID, i.DisplayName, i.InstallationVersion, i.InstallationPath}
+}
+
+// FormatTable formats installations as a text table with aligned columns.
+func FormatTable(installs []Installation) string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(tableHeader, "\t"))
+	for _, i := range installs {
+		fmt.Fprintln(tw, strings.Join(tableRow(i), "\t"))
+	}
+	_ = tw.Flush()
+	return buf.String()
+}
+
+// FormatMarkdown formats installations as a Markdown table.
+func FormatMarkdown(installs []Installation) string {
+	var sb strings.Builder
+	writeMarkdownRow(&sb, tableHeader)
+	sb.WriteString("|" + strings.Repeat(" --- |", len(tableHeader)) + "\n")
+	for _, i := range installs {
+		writeMarkdownRow(&sb, tableRow(i))
+	}
+	return sb.String()
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, c := range cells {
+		sb.WriteString(" " + strings.ReplaceAll(c, "|", `\|`) + " |")
+	}
+	sb.WriteString("\n")
+}
+
+// FormatTemplate executes the text/template text once per installation and
+// returns the concatenated output. For example, "{{.InstallationPath}}\n"
+// prints the path of each installation on its own line.
+func FormatTemplate(text string, installs []Installation) (string, error) {
+	tmpl, err := template.New("installation").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, i := range installs {
+		if err := tmpl.Execute(&buf, i); err != nil {
+			return "", fmt.Errorf("failed to execute template: %w", err)
+		}
+	}
+	return buf.String(), nil
+}
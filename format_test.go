@@ -0,0 +1,38 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var formatInstalls = []Installation{
+	{InstanceID: "abc", DisplayName: "Visual Studio Community 2019", InstallationVersion: "16.8.30804.86", InstallationPath: `C:\VS\2019`},
+	{InstanceID: "defghi", DisplayName: "Visual Studio Build Tools 2022", InstallationVersion: "17.9.34607.119", InstallationPath: `C:\VS\2022`},
+}
+
+func TestFormatTable(t *testing.T) {
+	expect := "" +
+		"INSTANCE  NAME                            VERSION         PATH\n" +
+		"abc       Visual Studio Community 2019    16.8.30804.86   C:\\VS\\2019\n" +
+		"defghi    Visual Studio Build Tools 2022  17.9.34607.119  C:\\VS\\2022\n"
+	require.Equal(t, expect, FormatTable(formatInstalls))
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	expect := "" +
+		"| INSTANCE | NAME | VERSION | PATH |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| abc | Visual Studio Community 2019 | 16.8.30804.86 | C:\\VS\\2019 |\n" +
+		"| defghi | Visual Studio Build Tools 2022 | 17.9.34607.119 | C:\\VS\\2022 |\n"
+	require.Equal(t, expect, FormatMarkdown(formatInstalls))
+}
+
+func TestFormatTemplate(t *testing.T) {
+	out, err := FormatTemplate("{{.InstanceID}}={{.InstallationVersion}}\n", formatInstalls)
+	require.NoError(t, err)
+	require.Equal(t, "abc=16.8.30804.86\ndefghi=17.9.34607.119\n", out)
+
+	_, err = FormatTemplate("{{.Missing", formatInstalls)
+	require.Error(t, err)
+}
@@ -2,4 +2,9 @@ module github.com/rfratto/vswhere
 
 go 1.15
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/BurntSushi/toml v1.2.1
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/sys v0.7.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
+)
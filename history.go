@@ -0,0 +1,212 @@
+package vswhere
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// HistoryEntry is a snapshot of installations recorded in a History.
+type HistoryEntry struct {
+	Time          time.Time      `json:"time"`
+	Installations []Installation `json:"installations"`
+}
+
+// HistoryChange is a change in an installation's version between two
+// snapshots in a History.
+type HistoryChange struct {
+	// Time of the snapshot which first saw the change.
+	Time       time.Time
+	InstanceID string
+
+	// From and To are the versions before and after the change. From is
+	// empty when the installation was added, and To is empty when it was
+	// removed.
+	From, To string
+}
+
+// History is an append-only log of installation snapshots, stored as one
+// JSON object per line, for auditing how a build environment drifts over
+// time.
+type History struct {
+	path    string
+	maxSize int64
+	finder  *Finder
+	now     func() time.Time
+}
+
+// HistoryOption configures a History.
+type HistoryOption func(h *History)
+
+// WithHistoryMaxSize rotates the log once it grows beyond size bytes. The
+// previous log is renamed with a ".1" suffix, replacing any older one, and
+// is still read by Entries. Logs aren't rotated by default.
+func WithHistoryMaxSize(size int64) HistoryOption {
+	return func(h *History) { h.maxSize = size }
+}
+
+// WithHistoryFinder finds installations with f when taking a Snapshot.
+// Defaults to the Finder returned by Default.
+func WithHistoryFinder(f *Finder) HistoryOption {
+	return func(h *History) { h.finder = f }
+}
+
+// NewHistory creates a History stored at path. The file is created when the
+// first snapshot is recorded.
+func NewHistory(path string, opts ...HistoryOption) *History {
+	h := History{path: path, now: time.Now}
+	for _, o := range opts {
+		o(&h)
+	}
+	return &h
+}
+
+// Snapshot finds installations and records them. Options can be provided to
+// customize the search; WithAll and WithPrerelease are typically wanted so
+// that no installation is missed.
+func (h *History) Snapshot(ctx context.Context, options ...Option) error {
+	f := h.finder
+	if f == nil {
+		f = Default()
+	}
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return err
+	}
+	return h.Record(installs)
+}
+
+// Record appends a snapshot of installs to the log.
+func (h *History) Record(installs []Installation) error {
+	if err := h.rotate(); err != nil {
+		return err
+	}
+
+	bb, err := json.Marshal(HistoryEntry{Time: h.now().UTC(), Installations: installs})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := f.Write(append(bb, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+func (h *History) rotate() error {
+	if h.maxSize <= 0 {
+		return nil
+	}
+	fi, err := os.Stat(h.path)
+	if os.IsNotExist(err) || (err == nil && fi.Size() < h.maxSize) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := os.Rename(h.path, h.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate history: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every snapshot in the log, oldest first.
+func (h *History) Entries() ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	for _, path := range []string{h.path + ".1", h.path} {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var e HistoryEntry
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("invalid history entry in %s: %w", path, err)
+			}
+			entries = append(entries, e)
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+// Changes returns the installations added, removed, or updated between
+// consecutive snapshots, oldest first. Installations in the first snapshot
+// are reported as added.
+func (h *History) Changes() ([]HistoryChange, error) {
+	entries, err := h.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes []HistoryChange
+		prev    []Installation
+	)
+	for _, e := range entries {
+		before := historyVersions(prev)
+		after := historyVersions(e.Installations)
+		for _, install := range e.Installations {
+			if from, ok := before[install.InstanceID]; !ok || from != install.InstallationVersion {
+				changes = append(changes, HistoryChange{
+					Time:       e.Time,
+					InstanceID: install.InstanceID,
+					From:       from,
+					To:         install.InstallationVersion,
+				})
+			}
+		}
+		for _, install := range prev {
+			if _, ok := after[install.InstanceID]; !ok {
+				changes = append(changes, HistoryChange{
+					Time:       e.Time,
+					InstanceID: install.InstanceID,
+					From:       install.InstallationVersion,
+				})
+			}
+		}
+		prev = e.Installations
+	}
+	return changes, nil
+}
+
+// historyVersions maps the instance ID of each installation to its version.
+func historyVersions(installs []Installation) map[string]string {
+	res := make(map[string]string, len(installs))
+	for _, install := range installs {
+		res[install.InstanceID] = install.InstallationVersion
+	}
+	return res
+}
+
+// LastUpdated returns when the installation with the given instance ID was
+// last seen with a new version, or false if the log has no such change.
+func (h *History) LastUpdated(instanceID string) (time.Time, bool, error) {
+	changes, err := h.Changes()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if c.InstanceID == instanceID && c.From != "" && c.To != "" {
+			return c.Time, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
@@ -0,0 +1,51 @@
+package vswhere
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	var (
+		path  = filepath.Join(t.TempDir(), "history.jsonl")
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		now   = start
+	)
+	h := NewHistory(path, WithHistoryMaxSize(1))
+	h.now = func() time.Time { now = now.Add(time.Hour); return now }
+
+	a := Installation{InstanceID: "a", InstallationVersion: "17.8.34330.188"}
+	b := Installation{InstanceID: "b", InstallationVersion: "16.11.34601.136"}
+	require.NoError(t, h.Record([]Installation{a, b}))
+	require.NoError(t, h.Record([]Installation{a, b}))
+
+	a.InstallationVersion = "17.9.34607.119"
+	require.NoError(t, h.Record([]Installation{a}))
+
+	// Only the current and previous logs are kept, so the first snapshot
+	// was lost to rotation.
+	entries, err := h.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	changes, err := h.Changes()
+	require.NoError(t, err)
+	require.Equal(t, []HistoryChange{
+		{Time: start.Add(2 * time.Hour), InstanceID: "a", To: "17.8.34330.188"},
+		{Time: start.Add(2 * time.Hour), InstanceID: "b", To: "16.11.34601.136"},
+		{Time: start.Add(3 * time.Hour), InstanceID: "a", From: "17.8.34330.188", To: "17.9.34607.119"},
+		{Time: start.Add(3 * time.Hour), InstanceID: "b", From: "16.11.34601.136"},
+	}, changes)
+
+	updated, ok, err := h.LastUpdated("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, start.Add(3*time.Hour), updated)
+
+	_, ok, err = h.LastUpdated("b")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,156 @@
+package vswhere
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InstallerLog is a log file written by the Visual Studio Installer.
+type InstallerLog struct {
+	// Path to the log file.
+	Path string
+
+	// Kind of log: "setup" for dd_setup_*.log or "bootstrapper" for
+	// dd_bootstrapper_*.log.
+	Kind string
+
+	// ModTime is when the log was last written.
+	ModTime time.Time
+}
+
+// InstallerLogs returns the installer's setup and bootstrapper logs in dir,
+// most recently written first. If dir is empty, the temporary directory
+// (%TEMP%) is used, which is where the installer writes its logs.
+func InstallerLogs(dir string) ([]InstallerLog, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installer logs: %w", err)
+	}
+
+	var logs []InstallerLog
+	for _, e := range entries {
+		name := strings.ToLower(e.Name())
+		if e.IsDir() || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		for _, kind := range []string{"setup", "bootstrapper"} {
+			if strings.HasPrefix(name, "dd_"+kind+"_") {
+				logs = append(logs, InstallerLog{
+					Path:    filepath.Join(dir, e.Name()),
+					Kind:    kind,
+					ModTime: e.ModTime(),
+				})
+			}
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].ModTime.After(logs[j].ModTime) })
+	return logs, nil
+}
+
+// InstallerLogEvent is a line of an installer log.
+type InstallerLogEvent struct {
+	Time time.Time
+
+	// Level is the severity of the event, such as "Error" or "Warning".
+	// Empty if the line has no level.
+	Level string
+
+	Message string
+}
+
+// InstallerLogSummary holds the key events of an installer log.
+type InstallerLogSummary struct {
+	// Start and End are the times of the first and last events.
+	Start, End time.Time
+
+	// Errors and Warnings are the events logged at those levels.
+	Errors   []InstallerLogEvent
+	Warnings []InstallerLogEvent
+
+	// ExitCode is the last exit code reported in the log. It is only set if
+	// Exited is true; a log without an exit code is usually from an
+	// installer which is still running or crashed.
+	ExitCode int
+	Exited   bool
+}
+
+// Duration returns how long the logged operation ran.
+func (s InstallerLogSummary) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+var (
+	// logLineRegex matches "[pid:tid][timestamp] message".
+	logLineRegex = regexp.MustCompile(`^\[[0-9a-fA-F]+:[0-9a-fA-F]+\]\[([^\]]+)\] ?(.*)$`)
+
+	// logLevelRegex matches the level at the start of a message.
+	logLevelRegex = regexp.MustCompile(`^(Error|Warning|Info|Verbose):\s*(.*)$`)
+
+	// exitCodeRegex matches an exit code reported by the installer, such as
+	// "Closing the installer with exit code 0".
+	exitCodeRegex = regexp.MustCompile(`(?i)exit(?:ed)?(?: with)? code:? (0x[0-9a-f]+|-?\d+)\b`)
+)
+
+// ParseInstallerLog summarizes the installer log read from r. Lines which
+// aren't in the installer's format, such as continuations of multi-line
+// messages, are ignored.
+func ParseInstallerLog(r io.Reader) (InstallerLogSummary, error) {
+	var summary InstallerLogSummary
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m := logLineRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		ts, err := time.ParseInLocation("2006-01-02T15:04:05", m[1], time.Local)
+		if err != nil {
+			continue
+		}
+
+		ev := InstallerLogEvent{Time: ts, Message: m[2]}
+		if lm := logLevelRegex.FindStringSubmatch(ev.Message); lm != nil {
+			ev.Level, ev.Message = lm[1], lm[2]
+		}
+
+		if summary.Start.IsZero() {
+			summary.Start = ts
+		}
+		summary.End = ts
+
+		switch ev.Level {
+		case "Error":
+			summary.Errors = append(summary.Errors, ev)
+		case "Warning":
+			summary.Warnings = append(summary.Warnings, ev)
+		}
+		if cm := exitCodeRegex.FindStringSubmatch(ev.Message); cm != nil {
+			// HRESULTs are logged in hex and stored as the signed value
+			// the process exited with.
+			if strings.HasPrefix(strings.ToLower(cm[1]), "0x") {
+				if code, err := strconv.ParseUint(cm[1][2:], 16, 32); err == nil {
+					summary.ExitCode, summary.Exited = int(int32(code)), true
+				}
+			} else if code, err := strconv.Atoi(cm[1]); err == nil {
+				summary.ExitCode, summary.Exited = code, true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return InstallerLogSummary{}, fmt.Errorf("failed to read installer log: %w", err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,51 @@
+package vswhere
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallerLogs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for name, age := range map[string]time.Duration{
+		"dd_setup_20240101.log":        2 * time.Hour,
+		"dd_bootstrapper_20240102.log": time.Hour,
+		"dd_client_20240102.log":       0,
+		"notes.txt":                    0,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+
+	logs, err := InstallerLogs(dir)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, filepath.Join(dir, "dd_bootstrapper_20240102.log"), logs[0].Path)
+	require.Equal(t, "bootstrapper", logs[0].Kind)
+	require.Equal(t, "setup", logs[1].Kind)
+}
+
+func TestParseInstallerLog(t *testing.T) {
+	log := `[1a2c:0001][2024-01-15T10:23:45] Setup version 3.8.2112.61926
+[1a2c:0007][2024-01-15T10:25:12] Warning: Retrying download of package 'Microsoft.VisualStudio.Foo'
+[1a2c:0007][2024-01-15T10:26:00] Error: Package 'Microsoft.VisualStudio.Foo' failed to install.
+    Return code: 1603
+[1a2c:0001][2024-01-15T10:30:45] Closing the installer with exit code 0x80070643
+`
+	summary, err := ParseInstallerLog(strings.NewReader(log))
+	require.NoError(t, err)
+	require.Equal(t, 7*time.Minute, summary.Duration())
+	require.Len(t, summary.Warnings, 1)
+	require.Len(t, summary.Errors, 1)
+	require.Equal(t, "Package 'Microsoft.VisualStudio.Foo' failed to install.", summary.Errors[0].Message)
+	require.True(t, summary.Exited)
+	require.Equal(t, int(int32(-2147023293)), summary.ExitCode)
+}
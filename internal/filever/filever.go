@@ -0,0 +1,41 @@
+//+build windows
+
+// Package filever reads the version resource of Windows executables.
+package filever
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Get returns the file version (e.g., "10.0.22621.755") of the executable or
+// DLL at path.
+func Get(path string) (string, error) {
+	size, err := windows.GetFileVersionInfoSize(path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read version of %s: %w", path, err)
+	}
+
+	buf := make([]byte, size)
+	if err := windows.GetFileVersionInfo(path, 0, size, unsafe.Pointer(&buf[0])); err != nil {
+		return "", fmt.Errorf("failed to read version of %s: %w", path, err)
+	}
+
+	var (
+		info    *windows.VS_FIXEDFILEINFO
+		infoLen uint32
+	)
+	err = windows.VerQueryValue(unsafe.Pointer(&buf[0]), `\`, unsafe.Pointer(&info), &infoLen)
+	if err != nil {
+		return "", fmt.Errorf("failed to read version of %s: %w", path, err)
+	}
+
+	return fmt.Sprintf("%d.%d.%d.%d",
+		info.FileVersionMS>>16,
+		info.FileVersionMS&0xffff,
+		info.FileVersionLS>>16,
+		info.FileVersionLS&0xffff,
+	), nil
+}
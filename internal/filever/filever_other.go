@@ -0,0 +1,11 @@
+//+build !windows
+
+package filever
+
+import "github.com/rfratto/vswhere/internal/platform"
+
+// Get returns the file version (e.g., "10.0.22621.755") of the executable or
+// DLL at path.
+func Get(path string) (string, error) {
+	return "", platform.ErrUnsupported
+}
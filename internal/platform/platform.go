@@ -0,0 +1,9 @@
+// Package platform holds errors shared by the platform-specific parts of the
+// module.
+package platform
+
+import "errors"
+
+// ErrUnsupported is returned on platforms other than Windows, where Visual
+// Studio can't be installed.
+var ErrUnsupported = errors.New("unsupported platform: Visual Studio requires Windows")
@@ -0,0 +1,29 @@
+// Package version compares dotted numeric version strings.
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare compares two dotted numeric versions, returning -1, 0, or 1.
+// Missing components are treated as 0.
+func Compare(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package vswhere
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rfratto/vswhere/internal/filever"
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// legacyNames maps the versions of Visual Studio 2010 through 2015 to their
+// display names.
+var legacyNames = map[string]string{
+	"10.0": "Visual Studio 2010",
+	"11.0": "Visual Studio 2012",
+	"12.0": "Visual Studio 2013",
+	"14.0": "Visual Studio 2015",
+}
+
+// LegacyInstallations returns the installations of Visual Studio 2010 through
+// 2015 recorded in the registry, newest first. These versions predate the
+// Setup Configuration API, so only the instance ID, path, version, display
+// name, and product path are known.
+func LegacyInstallations() ([]Installation, error) {
+	paths, err := legacyRegistryPaths()
+	if err != nil {
+		return nil, err
+	}
+	return legacyInstallations(paths), nil
+}
+
+func legacyInstallations(paths map[string]string) []Installation {
+	var installs []Installation
+	for ver, path := range paths {
+		name, ok := legacyNames[ver]
+		if !ok {
+			continue
+		}
+		install := Installation{
+			InstanceID:          "VisualStudio." + ver,
+			InstallationPath:    filepath.Clean(path),
+			InstallationVersion: ver,
+			DisplayName:         name,
+			IsComplete:          true,
+			IsLaunchable:        true,
+		}
+
+		// Express editions don't include devenv.exe.
+		devenv := filepath.Join(install.InstallationPath, "Common7", "IDE", "devenv.exe")
+		if _, err := os.Stat(devenv); err == nil {
+			install.ProductPath = devenv
+			if v, err := filever.Get(devenv); err == nil {
+				install.InstallationVersion = v
+			}
+		}
+		installs = append(installs, install)
+	}
+	sort.Slice(installs, func(i, j int) bool {
+		return version.Compare(installs[i].InstallationVersion, installs[j].InstallationVersion) > 0
+	})
+	return installs
+}
+
+// FindWithLegacy finds installations like Find and adds the installations of
+// Visual Studio 2010 through 2015 from LegacyInstallations, which have more
+// details than the ones reported by WithLegacy. Like WithLegacy, it can't be
+// combined with WithProducts or WithRequires.
+func FindWithLegacy(ctx context.Context, options ...Option) ([]Installation, error) {
+	return Default().FindWithLegacy(ctx, options...)
+}
+
+// FindWithLegacy finds installations including legacy ones using the Finder.
+func (f *Finder) FindWithLegacy(ctx context.Context, options ...Option) ([]Installation, error) {
+	options = append(options[:len(options):len(options)], WithLegacy(true))
+	so, err := f.newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := so.args(); err != nil {
+		return nil, err
+	}
+
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := readLegacy()
+	if err != nil {
+		return nil, err
+	}
+	return mergeLegacy(installs, legacy, &so)
+}
+
+// mergeLegacy adds the legacy installations matching so to installs,
+// replacing the less detailed ones vswhere reports with WithLegacy. The
+// result is ordered like the results of Find.
+func mergeLegacy(installs, legacy []Installation, so *searchOptions) ([]Installation, error) {
+	res := make([]Installation, 0, len(installs)+len(legacy))
+	for _, install := range installs {
+		if !hasInstance(legacy, install.InstanceID) {
+			res = append(res, install)
+		}
+	}
+
+	for _, install := range legacy {
+		if so.version != "" {
+			ok, err := inVersionRange(install.InstallationVersion, so.version)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		res = append(res, install)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return installKeyOf(res[i]).less(installKeyOf(res[j]), so.sort)
+	})
+	if so.latest && len(res) > 1 {
+		newest := 0
+		for i := range res {
+			if installKeyOf(res[i]).less(installKeyOf(res[newest]), true) {
+				newest = i
+			}
+		}
+		res = res[newest : newest+1]
+	}
+	return res, nil
+}
+
+func hasInstance(installs []Installation, instanceID string) bool {
+	for _, install := range installs {
+		if install.InstanceID == instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// CommonToolsPath returns the "Common7\Tools" directory of an installation,
+// which holds VsDevCmd.bat (or vsvars32.bat for Visual Studio 2015 and older).
+func CommonToolsPath(install Installation) string {
+	return filepath.Join(install.InstallationPath, "Common7", "Tools")
+}
@@ -0,0 +1,7 @@
+//+build !windows
+
+package vswhere
+
+func legacyRegistryPaths() (map[string]string, error) {
+	return nil, ErrUnsupportedPlatform
+}
@@ -0,0 +1,60 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyInstallations(t *testing.T) {
+	vs2013, vs2015 := t.TempDir(), t.TempDir()
+	installs := legacyInstallations(map[string]string{
+		"12.0": vs2013,
+		"14.0": vs2015,
+		"15.0": t.TempDir(), // Visual Studio 2017 is found by vswhere.
+	})
+	require.Len(t, installs, 2)
+	require.Equal(t, "VisualStudio.14.0", installs[0].InstanceID)
+	require.Equal(t, "Visual Studio 2015", installs[0].DisplayName)
+	require.Equal(t, vs2015, installs[0].InstallationPath)
+	require.Equal(t, filepath.Join(vs2015, "Common7", "Tools"), CommonToolsPath(installs[0]))
+	require.Equal(t, "VisualStudio.12.0", installs[1].InstanceID)
+
+	merged, err := mergeLegacy([]Installation{
+		{InstanceID: "modern", InstallationVersion: "17.8.34322.80"},
+		{InstanceID: "VisualStudio.14.0", InstallationVersion: "14.0"},
+	}, installs, &searchOptions{version: "[14.0,)"})
+	require.NoError(t, err)
+	require.Len(t, merged, 2)
+	require.Equal(t, "modern", merged[0].InstanceID)
+	require.Equal(t, "Visual Studio 2015", merged[1].DisplayName)
+}
+
+func TestFinderFindWithLegacy(t *testing.T) {
+	prev := readLegacy
+	readLegacy = func() ([]Installation, error) {
+		return []Installation{
+			{InstanceID: "VisualStudio.14.0", InstallationVersion: "14.0.25420.1", DisplayName: "Visual Studio 2015"},
+			{InstanceID: "VisualStudio.12.0", InstallationVersion: "12.0.40629.0", DisplayName: "Visual Studio 2013"},
+		}, nil
+	}
+	t.Cleanup(func() { readLegacy = prev })
+
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "VisualStudio.14.0", "installationVersion": "14.0"},
+  {"instanceId": "vs2019", "installationVersion": "16.11.34301.259"},
+  {"instanceId": "vs2022", "installationVersion": "17.8.34322.80"}
+]`}
+	installs, err := NewFinder(WithFinderRunner(r), WithBackends(BackendVswhere)).FindWithLegacy(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"-legacy", "-format", "json"}, r.args)
+
+	var ids []string
+	for _, install := range installs {
+		ids = append(ids, install.InstanceID)
+	}
+	require.Equal(t, []string{"vs2022", "vs2019", "VisualStudio.14.0", "VisualStudio.12.0"}, ids)
+	require.Equal(t, "Visual Studio 2015", installs[2].DisplayName)
+}
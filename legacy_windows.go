@@ -0,0 +1,37 @@
+//+build windows
+
+package vswhere
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// legacyKey lists the installation paths of Visual Studio 2017 and older,
+// keyed by version. It is read from the 32-bit registry view.
+const legacyKey = `SOFTWARE\Microsoft\VisualStudio\SxS\VS7`
+
+// legacyRegistryPaths returns the installation paths recorded in legacyKey,
+// keyed by version.
+func legacyRegistryPaths() (map[string]string, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, legacyKey, registry.READ|registry.WOW64_32KEY)
+	if err == registry.ErrNotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open VS7 registry key: %w", err)
+	}
+	defer k.Close()
+
+	names, err := k.ReadValueNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legacy installations: %w", err)
+	}
+	paths := make(map[string]string, len(names))
+	for _, name := range names {
+		if path, _, err := k.GetStringValue(name); err == nil && path != "" {
+			paths[name] = path
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,22 @@
+package vswhere
+
+// Machine describes the host characteristics needed to interpret a Visual
+// Studio inventory.
+type Machine struct {
+	// OSVersion is the Windows version (e.g., "10.0.22631").
+	OSVersion string `json:"osVersion"`
+
+	// Arch is the native architecture of the machine, regardless of the
+	// architecture of the current process.
+	Arch Arch `json:"arch"`
+
+	// Container is true if running inside a Windows container.
+	Container bool `json:"container"`
+
+	// VirtualMachine is true if the machine appears to be a virtual machine,
+	// based on its reported system manufacturer and product name.
+	VirtualMachine bool `json:"virtualMachine"`
+
+	// Elevated is true if the current process is running elevated.
+	Elevated bool `json:"elevated"`
+}
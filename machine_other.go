@@ -0,0 +1,8 @@
+//+build !windows
+
+package vswhere
+
+// MachineInfo returns information about the current machine.
+func MachineInfo() (Machine, error) {
+	return Machine{}, ErrUnsupportedPlatform
+}
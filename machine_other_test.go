@@ -0,0 +1,15 @@
+//+build !windows
+
+package vswhere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineInfoUnsupported(t *testing.T) {
+	_, err := MachineInfo()
+	require.True(t, errors.Is(err, ErrUnsupportedPlatform))
+}
@@ -0,0 +1,93 @@
+//+build windows
+
+package vswhere
+
+import (
+	"debug/pe"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// MachineInfo returns information about the current machine.
+func MachineInfo() (Machine, error) {
+	v := windows.RtlGetVersion()
+	m := Machine{
+		OSVersion: fmt.Sprintf("%d.%d.%d", v.MajorVersion, v.MinorVersion, v.BuildNumber),
+		Arch:      nativeArch(),
+		Elevated:  windows.GetCurrentProcessToken().IsElevated(),
+	}
+
+	if k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Control`, registry.QUERY_VALUE); err == nil {
+		_, _, err := k.GetIntegerValue("ContainerType")
+		m.Container = err == nil
+		k.Close()
+	}
+
+	if k, err := registry.OpenKey(registry.LOCAL_MACHINE, `HARDWARE\DESCRIPTION\System\BIOS`, registry.QUERY_VALUE); err == nil {
+		manufacturer, _, _ := k.GetStringValue("SystemManufacturer")
+		product, _, _ := k.GetStringValue("SystemProductName")
+		m.VirtualMachine = isVirtual(manufacturer + " " + product)
+		k.Close()
+	}
+	return m, nil
+}
+
+// virtualMarkers are substrings of the system manufacturer or product name of
+// common hypervisors.
+var virtualMarkers = []string{
+	"virtual machine", // Hyper-V and Azure
+	"vmware",
+	"virtualbox",
+	"qemu",
+	"kvm",
+	"xen",
+	"amazon ec2",
+	"google compute engine",
+	"parallels",
+}
+
+func isVirtual(system string) bool {
+	system = strings.ToLower(system)
+	for _, marker := range virtualMarkers {
+		if strings.Contains(system, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func nativeArch() Arch {
+	var processMachine, nativeMachine uint16
+	if err := windows.IsWow64Process2(windows.CurrentProcess(), &processMachine, &nativeMachine); err == nil {
+		switch nativeMachine {
+		case pe.IMAGE_FILE_MACHINE_I386:
+			return X86
+		case pe.IMAGE_FILE_MACHINE_AMD64:
+			return X64
+		case pe.IMAGE_FILE_MACHINE_ARMNT:
+			return ARM
+		case pe.IMAGE_FILE_MACHINE_ARM64:
+			return ARM64
+		}
+	}
+
+	// IsWow64Process2 requires Windows 10 1511; fall back to the environment.
+	arch := os.Getenv("PROCESSOR_ARCHITEW6432")
+	if arch == "" {
+		arch = os.Getenv("PROCESSOR_ARCHITECTURE")
+	}
+	switch strings.ToUpper(arch) {
+	case "AMD64":
+		return X64
+	case "ARM64":
+		return ARM64
+	case "ARM":
+		return ARM
+	default:
+		return X86
+	}
+}
@@ -0,0 +1,100 @@
+// Package metrics exposes Visual Studio installations as Prometheus metrics,
+// for monitoring how up to date the toolchains of build agents are.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rfratto/vswhere"
+)
+
+// Handler returns an http.Handler serving metrics in the Prometheus text
+// format. Installations are found with f on every request, with options
+// customizing the search. If f is nil, vswhere.Default is used, looked up on
+// each request so later calls to vswhere.SetDefault take effect.
+func Handler(f *vswhere.Finder, options ...vswhere.Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		finder := f
+		if finder == nil {
+			finder = vswhere.Default()
+		}
+		installs, err := finder.Find(r.Context(), options...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_ = Write(w, installs, time.Now())
+	})
+}
+
+// Write writes metrics describing installs to w in the Prometheus text format.
+// now is used to compute the time since each installation was updated.
+func Write(w io.Writer, installs []vswhere.Installation, now time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	type group struct{ product, version, channel string }
+	counts := make(map[group]int)
+	for _, i := range installs {
+		counts[group{i.ProductID, i.InstallationVersion, i.ChannelID}]++
+	}
+	groups := make([]group, 0, len(counts))
+	for g := range counts {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.product != b.product {
+			return a.product < b.product
+		}
+		if a.version != b.version {
+			return a.version < b.version
+		}
+		return a.channel < b.channel
+	})
+
+	fmt.Fprintln(bw, "# HELP vswhere_instances Number of Visual Studio instances.")
+	fmt.Fprintln(bw, "# TYPE vswhere_instances gauge")
+	for _, g := range groups {
+		fmt.Fprintf(bw, "vswhere_instances{product=%s,version=%s,channel=%s} %d\n",
+			quote(g.product), quote(g.version), quote(g.channel), counts[g])
+	}
+
+	fmt.Fprintln(bw, "# HELP vswhere_reboot_required Whether an instance requires a reboot to finish installing.")
+	fmt.Fprintln(bw, "# TYPE vswhere_reboot_required gauge")
+	for _, i := range installs {
+		var v int
+		if i.IsRebootRequired {
+			v = 1
+		}
+		fmt.Fprintf(bw, "vswhere_reboot_required{instance=%s} %d\n", quote(i.InstanceID), v)
+	}
+
+	fmt.Fprintln(bw, "# HELP vswhere_days_since_update Days since an instance was last updated or installed.")
+	fmt.Fprintln(bw, "# TYPE vswhere_days_since_update gauge")
+	for _, i := range installs {
+		updated := i.UpdateDate
+		if updated.IsZero() {
+			updated = i.InstallDate
+		}
+		if updated.IsZero() {
+			continue
+		}
+		fmt.Fprintf(bw, "vswhere_days_since_update{instance=%s} %g\n", quote(i.InstanceID), now.Sub(updated).Hours()/24)
+	}
+
+	return bw.Flush()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote quotes a label value.
+func quote(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rfratto/vswhere"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+	installs := []vswhere.Installation{
+		{
+			InstanceID:          "a",
+			InstallationVersion: "17.8.34322.80",
+			ProductID:           "Microsoft.VisualStudio.Product.Community",
+			ChannelID:           "VisualStudio.17.Release",
+			UpdateDate:          now.Add(-36 * time.Hour),
+		},
+		{
+			InstanceID:          "b",
+			InstallationVersion: "17.8.34322.80",
+			ProductID:           "Microsoft.VisualStudio.Product.Community",
+			ChannelID:           "VisualStudio.17.Release",
+			InstallDate:         now.Add(-240 * time.Hour),
+			IsRebootRequired:    true,
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, installs, now))
+	require.Equal(t, `# HELP vswhere_instances Number of Visual Studio instances.
+# TYPE vswhere_instances gauge
+vswhere_instances{product="Microsoft.VisualStudio.Product.Community",version="17.8.34322.80",channel="VisualStudio.17.Release"} 2
+# HELP vswhere_reboot_required Whether an instance requires a reboot to finish installing.
+# TYPE vswhere_reboot_required gauge
+vswhere_reboot_required{instance="a"} 0
+vswhere_reboot_required{instance="b"} 1
+# HELP vswhere_days_since_update Days since an instance was last updated or installed.
+# TYPE vswhere_days_since_update gauge
+vswhere_days_since_update{instance="a"} 1.5
+vswhere_days_since_update{instance="b"} 10
+`, buf.String())
+}
+
+type fakeRunner struct{ stdout string }
+
+func (r fakeRunner) Run(ctx context.Context, args []string) ([]byte, []byte, error) {
+	return []byte(r.stdout), nil, nil
+}
+
+func TestHandlerDefault(t *testing.T) {
+	t.Cleanup(func() { vswhere.SetDefault(nil) })
+	h := Handler(nil)
+
+	// The default Finder is looked up per request, so SetDefault after
+	// creating the handler still applies.
+	vswhere.SetDefault(vswhere.NewFinder(vswhere.WithFinderRunner(fakeRunner{
+		stdout: `[{"instanceId": "abc", "productId": "Microsoft.VisualStudio.Product.BuildTools", "installationVersion": "17.8.34322.80"}]`,
+	})))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	require.Contains(t, rec.Body.String(), `product="Microsoft.VisualStudio.Product.BuildTools"`)
+}
@@ -0,0 +1,107 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// MSBuildComponent is the component ID of MSBuild.
+const MSBuildComponent = "Microsoft.Component.MSBuild"
+
+// VersionForMSBuild returns the range of Visual Studio versions shipping an
+// MSBuild version, using the same syntax as WithVersion. msbuild may be a
+// version such as "16.11" or "17", or "Current", which is the MSBuild
+// directory name used since Visual Studio 2019. MSBuild versions match the
+// major version of the Visual Studio they ship with.
+func VersionForMSBuild(msbuild string) (string, error) {
+	if strings.EqualFold(msbuild, "Current") {
+		return "16.0", nil
+	}
+
+	major, err := strconv.Atoi(strings.SplitN(msbuild, ".", 2)[0])
+	if err != nil || major < 15 {
+		return "", fmt.Errorf("unsupported MSBuild version %q", msbuild)
+	}
+	return fmt.Sprintf("[%d.0,%d.0)", major, major+1), nil
+}
+
+// MSBuildPath returns the path to MSBuild.exe within an installation.
+func MSBuildPath(install Installation) (string, error) {
+	for _, dir := range []string{"Current", "15.0"} {
+		path := filepath.Join(install.InstallationPath, "MSBuild", dir, "Bin", "MSBuild.exe")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("MSBuild not found in %s", install.InstallationPath)
+}
+
+// FindMSBuild finds the newest installation with MSBuild matching an MSBuild
+// version (see VersionForMSBuild) and returns the path to its MSBuild.exe.
+// All products are searched, including Build Tools, unless WithProducts is
+// given. A range given with WithVersion is narrowed to the versions shipping
+// the MSBuild version, returning an error wrapping ErrInvalidArgument if they
+// don't overlap. Options can be provided to further customize the search.
+func FindMSBuild(ctx context.Context, msbuild string, options ...Option) (string, Installation, error) {
+	return Default().FindMSBuild(ctx, msbuild, options...)
+}
+
+// FindMSBuild finds the newest installation with MSBuild using the Finder.
+func (f *Finder) FindMSBuild(ctx context.Context, msbuild string, options ...Option) (string, Installation, error) {
+	versionRange, err := VersionForMSBuild(msbuild)
+	if err != nil {
+		return "", Installation{}, err
+	}
+
+	r, err := ParseVersionRange(versionRange)
+	if err != nil {
+		return "", Installation{}, err
+	}
+	so, err := f.newSearchOptions(options)
+	if err != nil {
+		return "", Installation{}, err
+	}
+	if so.version != "" {
+		given, err := ParseVersionRange(so.version)
+		if err != nil {
+			return "", Installation{}, err
+		}
+		if r = r.Intersect(given); r.Validate() != nil {
+			return "", Installation{}, fmt.Errorf("%w: MSBuild %s isn't shipped by versions %s", ErrInvalidArgument, msbuild, so.version)
+		}
+	}
+
+	// Search every product unless the caller or the Finder's config chose
+	// some; the config is applied before options, so prepending WithProducts
+	// alone would override it.
+	if len(so.products) == 0 {
+		options = append([]Option{WithProducts([]string{"*"})}, options...)
+	}
+	options = append(options, WithVersionRange(r), withRequired(MSBuildComponent))
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return "", Installation{}, err
+	}
+
+	var newest *Installation
+	for i, install := range installs {
+		if newest == nil || version.Compare(install.InstallationVersion, newest.InstallationVersion) > 0 {
+			newest = &installs[i]
+		}
+	}
+	if newest == nil {
+		return "", Installation{}, fmt.Errorf("%w: none provide MSBuild %s", ErrNoInstallations, msbuild)
+	}
+
+	path, err := MSBuildPath(*newest)
+	if err != nil {
+		return "", Installation{}, err
+	}
+	return path, *newest, nil
+}
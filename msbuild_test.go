@@ -0,0 +1,77 @@
+package vswhere
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionForMSBuild(t *testing.T) {
+	for msbuild, expect := range map[string]string{
+		"15":      "[15.0,16.0)",
+		"16.11":   "[16.0,17.0)",
+		"17.9.8":  "[17.0,18.0)",
+		"current": "16.0",
+	} {
+		r, err := VersionForMSBuild(msbuild)
+		require.NoError(t, err)
+		require.Equal(t, expect, r, msbuild)
+	}
+
+	_, err := VersionForMSBuild("14.0")
+	require.Error(t, err)
+}
+
+func TestMSBuildPath(t *testing.T) {
+	vs2017 := t.TempDir()
+	mkfile(t, vs2017, "MSBuild", "15.0", "Bin", "MSBuild.exe")
+	path, err := MSBuildPath(Installation{InstallationPath: vs2017})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(vs2017, "MSBuild", "15.0", "Bin", "MSBuild.exe"), path)
+
+	vs2022 := t.TempDir()
+	mkfile(t, vs2022, "MSBuild", "Current", "Bin", "MSBuild.exe")
+	path, err = MSBuildPath(Installation{InstallationPath: vs2022})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(vs2022, "MSBuild", "Current", "Bin", "MSBuild.exe"), path)
+
+	_, err = MSBuildPath(Installation{InstallationPath: t.TempDir()})
+	require.Error(t, err)
+}
+
+func TestFinderFindMSBuild(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "MSBuild", "Current", "Bin", "MSBuild.exe")
+	r := &fakeRunner{stdout: `[{
+  "instanceId": "a",
+  "installationPath": "` + filepath.ToSlash(root) + `",
+  "installationVersion": "17.9.34607.119",
+  "packages": [{"id": "` + MSBuildComponent + `"}]
+}]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	path, install, err := f.FindMSBuild(context.Background(), "17", WithVersion("[17.5,18.5)"))
+	require.NoError(t, err)
+	require.Equal(t, "a", install.InstanceID)
+	require.Equal(t, filepath.Join(root, "MSBuild", "Current", "Bin", "MSBuild.exe"), path)
+	require.Equal(t, []string{
+		"-products", "*", "-requires", MSBuildComponent, "-version", "[17.5,18.0)",
+		"-include", "packages", "-format", "json",
+	}, r.args)
+
+	_, _, err = f.FindMSBuild(context.Background(), "16", WithVersion("[17.0,18.0)"))
+	require.True(t, errors.Is(err, ErrInvalidArgument))
+
+	// Products from the caller or the config aren't replaced.
+	_, _, err = f.FindMSBuild(context.Background(), "17", WithProducts([]string{"Microsoft.VisualStudio.Product.BuildTools"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-products", "Microsoft.VisualStudio.Product.BuildTools"}, r.args[:2])
+
+	f = NewFinder(WithFinderRunner(r), WithFinderConfig(Config{Products: []string{"Microsoft.VisualStudio.Product.Enterprise"}}))
+	_, _, err = f.FindMSBuild(context.Background(), "17")
+	require.NoError(t, err)
+	require.Equal(t, []string{"-products", "Microsoft.VisualStudio.Product.Enterprise"}, r.args[:2])
+}
@@ -0,0 +1,58 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetByNickname returns the installation given the nickname name in the
+// Visual Studio Installer, ignoring case. Returns an error if no installation
+// or more than one installation has the nickname, or if name is empty.
+// Nicknames are only reported by vswhere.exe, so an error explains when
+// another backend answered the search. Prerelease and incomplete
+// installations of every product are considered; options can be provided to
+// further customize the search.
+func GetByNickname(ctx context.Context, name string, options ...Option) (Installation, error) {
+	return Default().GetByNickname(ctx, name, options...)
+}
+
+// GetByNickname returns the installation given the nickname name in the
+// Visual Studio Installer, ignoring case.
+func (f *Finder) GetByNickname(ctx context.Context, name string, options ...Option) (Installation, error) {
+	if name == "" {
+		return Installation{}, fmt.Errorf("%w: empty nickname", ErrInvalidArgument)
+	}
+
+	searchOpts := append([]Option{WithAll(true), WithPrerelease(true), WithProducts([]string{"*"})}, options...)
+	so, err := f.newSearchOptions(searchOpts)
+	if err != nil {
+		return Installation{}, err
+	}
+	var info RunInfo
+	installs, err := f.Find(ctx, append(searchOpts, WithRunInfo(&info))...)
+	if so.runInfo != nil {
+		*so.runInfo = info
+	}
+	if err != nil {
+		return Installation{}, err
+	}
+
+	var matches []Installation
+	for _, install := range installs {
+		if strings.EqualFold(install.Properties.Nickname, name) {
+			matches = append(matches, install)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		if info.Backend == BackendSetupConfiguration || info.Backend == BackendStateFiles {
+			return Installation{}, fmt.Errorf("%w with nickname %s: the %s backend doesn't report nicknames", ErrInstallationNotFound, name, info.Backend)
+		}
+		return Installation{}, fmt.Errorf("%w with nickname %s", ErrInstallationNotFound, name)
+	case 1:
+		return matches[0], nil
+	default:
+		return Installation{}, fmt.Errorf("%d installs have nickname %s", len(matches), name)
+	}
+}
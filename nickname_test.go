@@ -0,0 +1,39 @@
+package vswhere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByNickname(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "properties": {"nickname": "release"}},
+  {"instanceId": "b", "properties": {"nickname": "preview"}},
+  {"instanceId": "c", "properties": {"nickname": "Preview"}},
+  {"instanceId": "d"}
+]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	install, err := f.GetByNickname(context.Background(), "Release")
+	require.NoError(t, err)
+	require.Equal(t, "a", install.InstanceID)
+	require.Equal(t, []string{"-all", "-prerelease", "-products", "*", "-format", "json"}, r.args)
+
+	_, err = f.GetByNickname(context.Background(), "preview")
+	require.EqualError(t, err, "2 installs have nickname preview")
+	_, err = f.GetByNickname(context.Background(), "gamebuild")
+	require.EqualError(t, err, "installation not found with nickname gamebuild")
+	_, err = f.GetByNickname(context.Background(), "")
+	require.True(t, errors.Is(err, ErrInvalidArgument))
+
+	// The state files don't record nicknames.
+	dir := t.TempDir()
+	writeState(t, dir, `{"installationPath": "C:\\VS", "product": {"id": "Microsoft.VisualStudio.Product.Community"}}`, "a")
+	var info RunInfo
+	_, err = NewFinder(WithFinderOffline(dir)).GetByNickname(context.Background(), "release", WithRunInfo(&info))
+	require.EqualError(t, err, "installation not found with nickname release: the state files backend doesn't report nicknames")
+	require.Equal(t, BackendStateFiles, info.Backend)
+}
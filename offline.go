@@ -0,0 +1,190 @@
+package vswhere
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// stateFile is the subset of an instance's state.json used to build an
+// Installation.
+type stateFile struct {
+	InstallationName    string    `json:"installationName"`
+	InstallationPath    string    `json:"installationPath"`
+	InstallationVersion string    `json:"installationVersion"`
+	InstallDate         time.Time `json:"installDate"`
+	UpdateDate          time.Time `json:"updateDate"`
+	ChannelID           string    `json:"channelId"`
+	ChannelURI          string    `json:"channelUri"`
+	EnginePath          string    `json:"enginePath"`
+	ReleaseNotes        string    `json:"releaseNotes"`
+	ThirdPartyNotices   string    `json:"thirdPartyNotices"`
+	CatalogInfo         Catalog   `json:"catalogInfo"`
+	Product             struct {
+		ID string `json:"id"`
+	} `json:"product"`
+	LaunchParams struct {
+		FileName string `json:"fileName"`
+	} `json:"launchParams"`
+	LocalizedResources []struct {
+		Language    string `json:"language"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"localizedResources"`
+	Packages []Package `json:"packages"`
+}
+
+// defaultStateDir returns the directory holding the state of each instance.
+func defaultStateDir() string {
+	return filepath.Join(os.Getenv("ProgramData"), "Microsoft", "VisualStudio", "Packages", "_Instances")
+}
+
+// ReadStateDir reads the installations recorded by the Visual Studio
+// Installer in dir, which holds one subdirectory per instance containing a
+// state.json file. If dir is empty,
+// "%ProgramData%\Microsoft\VisualStudio\Packages\_Instances" is used.
+//
+// No process is run, making ReadStateDir much faster than Find, but the
+// installer's state files aren't a stable format. Installations are assumed
+// to be complete and launchable, and their properties aren't reported. Their
+// packages are reported as though WithIncludePackages was given.
+func ReadStateDir(dir string) ([]Installation, error) {
+	if dir == "" {
+		dir = defaultStateDir()
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	var installs []Installation
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name(), "state.json")
+		bb, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var state stateFile
+		if err := json.Unmarshal(bb, &state); err != nil {
+			return nil, fmt.Errorf("invalid state file %s: %w", path, err)
+		}
+		installs = append(installs, state.installation(e.Name()))
+	}
+	return installs, nil
+}
+
+func (s *stateFile) installation(instanceID string) Installation {
+	install := Installation{
+		InstanceID:          instanceID,
+		InstallDate:         s.InstallDate,
+		InstallationName:    s.InstallationName,
+		InstallationPath:    s.InstallationPath,
+		InstallationVersion: s.InstallationVersion,
+		ProductID:           s.Product.ID,
+		IsComplete:          true,
+		IsLaunchable:        true,
+		IsPrerelease:        strings.EqualFold(s.CatalogInfo.ProductMilestoneIsPrerelease, "true"),
+		ChannelID:           s.ChannelID,
+		ChannelURI:          s.ChannelURI,
+		EnginePath:          s.EnginePath,
+		ReleaseNotes:        s.ReleaseNotes,
+		ThirdPartyNotices:   s.ThirdPartyNotices,
+		UpdateDate:          s.UpdateDate,
+		Catalog:             s.CatalogInfo,
+		Packages:            s.Packages,
+	}
+	if s.LaunchParams.FileName != "" {
+		install.ProductPath = filepath.Join(s.InstallationPath, s.LaunchParams.FileName)
+	}
+
+	// Prefer English resources, which vswhere reports by default.
+	for _, r := range s.LocalizedResources {
+		if install.DisplayName == "" || strings.HasPrefix(strings.ToLower(r.Language), "en") {
+			install.DisplayName, install.Description = r.Title, r.Description
+		}
+	}
+	return install
+}
+
+// stateInstallations finds installations from the state files in dir,
+// applying the same filters as vswhere. If root is set, the paths of each
+// installation are rewritten to be beneath it.
+func stateInstallations(dir, root string, so *searchOptions) ([]Installation, error) {
+	if len(so.requires) > 0 {
+		return nil, fmt.Errorf("%w: WithRequires is not supported when reading state files", errBackendUnavailable)
+	}
+	if dir == "" {
+		dir = defaultStateDir()
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s not found", errBackendUnavailable, dir)
+	}
+
+	all, err := ReadStateDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var installs []Installation
+	for _, install := range all {
+		if !so.includePackages() {
+			install.Packages = nil
+		}
+		if root != "" {
+			for _, p := range []*string{&install.InstallationPath, &install.ProductPath, &install.EnginePath} {
+				*p = imagePath(root, *p)
+			}
+		}
+		if so.path != "" {
+			if containsPath(install.InstallationPath, so.path) {
+				installs = append(installs, install)
+			}
+			continue
+		}
+		ok, err := so.matches(install)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			installs = append(installs, install)
+		}
+	}
+	return so.filterLatest(installs), nil
+}
+
+// WithFinderImage reads installations from an offline Windows image, such as
+// a mounted VHD, whose system drive is mounted at root. The installer's state
+// files are read from the image's ProgramData directory, and the paths of
+// each installation are rewritten from the image's drive letter to be beneath
+// root.
+//
+// Visual Studio 2015 and older are only recorded in the image's registry
+// hive and aren't reported. WithRequires isn't supported.
+func WithFinderImage(root string) FinderOption {
+	return func(f *Finder) {
+		f.backends = []Backend{BackendStateFiles}
+		f.stateDir = filepath.Join(root, "ProgramData", "Microsoft", "VisualStudio", "Packages", "_Instances")
+		f.imageRoot = root
+	}
+}
+
+// imagePath rewrites path, as recorded inside an image, to be beneath root.
+// Paths without a drive letter are returned unchanged.
+func imagePath(root, path string) string {
+	if len(path) < 2 || path[1] != ':' {
+		return path
+	}
+	rel := strings.ReplaceAll(path[2:], `\`, "/")
+	return filepath.Join(root, filepath.FromSlash(rel))
+}
@@ -0,0 +1,104 @@
+package vswhere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStateDir(t *testing.T) {
+	dir := t.TempDir()
+	writeState(t, dir, `{
+  "installationName": "VisualStudio/17.8.3+34330.188",
+  "installationPath": "C:\\Program Files\\Microsoft Visual Studio\\2022\\Community",
+  "installationVersion": "17.8.34330.188",
+  "installDate": "2023-11-20T10:11:12Z",
+  "channelId": "VisualStudio.17.Release",
+  "product": {"id": "Microsoft.VisualStudio.Product.Community"},
+  "launchParams": {"fileName": "Common7\\IDE\\devenv.exe"},
+  "catalogInfo": {"productDisplayVersion": "17.8.3", "productMilestoneIsPreRelease": "False"},
+  "localizedResources": [
+    {"language": "de-de", "title": "Visual Studio Community 2022 (DE)"},
+    {"language": "en-us", "title": "Visual Studio Community 2022", "description": "IDE"}
+  ],
+  "packages": [{"id": "Microsoft.VisualStudio.Workload.NativeDesktop", "version": "17.8.34129.139", "type": "Workload"}]
+}`, "1a2b3c4d")
+	writeState(t, dir, `{
+  "installationPath": "C:\\Program Files\\Microsoft Visual Studio\\2022\\Preview",
+  "installationVersion": "17.9.34321.82",
+  "product": {"id": "Microsoft.VisualStudio.Product.Community"},
+  "catalogInfo": {"productMilestoneIsPreRelease": "True"}
+}`, "5e6f7a8b")
+
+	installs, err := ReadStateDir(dir)
+	require.NoError(t, err)
+	require.Len(t, installs, 2)
+
+	i := installs[0]
+	require.Equal(t, "1a2b3c4d", i.InstanceID)
+	require.Equal(t, "17.8.34330.188", i.InstallationVersion)
+	require.Equal(t, "Microsoft.VisualStudio.Product.Community", i.ProductID)
+	require.Equal(t, "Visual Studio Community 2022", i.DisplayName)
+	require.Equal(t, "17.8.3", i.Catalog.ProductDisplayVersion)
+	require.Equal(t, time.Date(2023, 11, 20, 10, 11, 12, 0, time.UTC), i.InstallDate)
+	require.False(t, i.IsPrerelease)
+	require.True(t, installs[1].IsPrerelease)
+
+	f := NewFinder(WithFinderOffline(dir))
+	found, err := f.Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "1a2b3c4d", found[0].InstanceID)
+	require.Empty(t, found[0].Packages)
+
+	found, err = f.Find(context.Background(), WithIncludePackages(true))
+	require.NoError(t, err)
+	require.Equal(t, []Package{{ID: "Microsoft.VisualStudio.Workload.NativeDesktop", Version: "17.8.34129.139", Type: "Workload"}}, found[0].Packages)
+
+	found, err = f.Find(context.Background(), WithPrerelease(true), WithLatest(true))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "5e6f7a8b", found[0].InstanceID)
+
+	install, err := f.Get(context.Background(), `C:\Program Files\Microsoft Visual Studio\2022\Preview`)
+	require.NoError(t, err)
+	require.Equal(t, "5e6f7a8b", install.InstanceID)
+
+	installs, err = ReadStateDir(dir + "-missing")
+	require.NoError(t, err)
+	require.Empty(t, installs)
+}
+
+func writeState(t *testing.T, dir, content, instanceID string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, instanceID), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, instanceID, "state.json"), []byte(content), 0644))
+}
+
+func TestFinderImage(t *testing.T) {
+	root := t.TempDir()
+	writeState(t, filepath.Join(root, "ProgramData", "Microsoft", "VisualStudio", "Packages", "_Instances"), `{
+  "installationPath": "C:\\Program Files\\Microsoft Visual Studio\\2022\\BuildTools",
+  "installationVersion": "17.8.34330.188",
+  "product": {"id": "Microsoft.VisualStudio.Product.BuildTools"},
+  "launchParams": {"fileName": "Common7\\Tools\\LaunchDevCmd.bat"}
+}`, "1a2b3c4d")
+
+	f := NewFinder(WithFinderImage(root))
+	installs, err := f.Find(context.Background(), WithProducts([]string{"*"}))
+	require.NoError(t, err)
+	require.Len(t, installs, 1)
+
+	path := filepath.Join(root, "Program Files", "Microsoft Visual Studio", "2022", "BuildTools")
+	require.Equal(t, path, installs[0].InstallationPath)
+	require.Equal(t, filepath.Join(path, "Common7", "Tools", "LaunchDevCmd.bat"), installs[0].ProductPath)
+
+	install, err := f.Get(context.Background(), path)
+	require.NoError(t, err)
+	require.Equal(t, "1a2b3c4d", install.InstanceID)
+}
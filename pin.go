@@ -0,0 +1,134 @@
+package vswhere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// PinFileName is the name of the file recording a pinned installation.
+const PinFileName = ".vs-instance"
+
+// pinFile is the contents of a pin file.
+type pinFile struct {
+	InstanceID          string `json:"instanceId"`
+	Fingerprint         string `json:"fingerprint,omitempty"`
+	InstallationVersion string `json:"installationVersion"`
+}
+
+// Pin records install in a pin file within dir so that Resolve called from dir
+// or any of its subdirectories returns the same installation. The pin file is
+// meant to be committed to the repository.
+//
+// The installation's Fingerprint is also recorded, so the pin continues to
+// resolve if the installation is reinstalled with a new instance ID.
+func Pin(dir string, install Installation) error {
+	bb, err := json.MarshalIndent(pinFile{
+		InstanceID:          install.InstanceID,
+		Fingerprint:         Fingerprint(install),
+		InstallationVersion: install.InstallationVersion,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, PinFileName), append(bb, '\n'), 0644)
+}
+
+// DriftError is returned by Resolve when the pinned installation has been
+// updated since it was pinned.
+type DriftError struct {
+	PinPath          string
+	PinnedVersion    string
+	InstalledVersion string
+}
+
+// Error implements error.
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("installation pinned in %s has drifted from version %s to %s", e.PinPath, e.PinnedVersion, e.InstalledVersion)
+}
+
+// Resolve searches dir and its parents for a pin file and returns the pinned
+// installation. If the installation's version no longer matches the pinned
+// version, the installation is returned along with a *DriftError.
+func Resolve(ctx context.Context, dir string) (Installation, error) {
+	return Default().Resolve(ctx, dir)
+}
+
+// Resolve returns the installation pinned for dir using the Finder.
+func (f *Finder) Resolve(ctx context.Context, dir string) (Installation, error) {
+	path, err := findPinFile(dir)
+	if err != nil {
+		return Installation{}, err
+	}
+
+	bb, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Installation{}, err
+	}
+	var pin pinFile
+	if err := json.Unmarshal(bb, &pin); err != nil {
+		return Installation{}, fmt.Errorf("invalid pin file %s: %w", path, err)
+	}
+
+	installs, err := f.Find(ctx, WithAll(true), WithPrerelease(true), WithProducts([]string{"*"}))
+	if err != nil {
+		return Installation{}, err
+	}
+	install, ok := findPinned(installs, pin)
+	if !ok {
+		return Installation{}, fmt.Errorf("%w: %s pinned in %s", ErrInstallationNotFound, pin.InstanceID, path)
+	}
+	if install.InstallationVersion != pin.InstallationVersion {
+		return install, &DriftError{
+			PinPath:          path,
+			PinnedVersion:    pin.InstallationVersion,
+			InstalledVersion: install.InstallationVersion,
+		}
+	}
+	return install, nil
+}
+
+// findPinned returns the installation recorded by pin, matching by instance ID
+// and then by fingerprint.
+func findPinned(installs []Installation, pin pinFile) (Installation, bool) {
+	for _, install := range installs {
+		if install.InstanceID == pin.InstanceID {
+			return install, true
+		}
+	}
+	if pin.Fingerprint == "" {
+		return Installation{}, false
+	}
+	for _, install := range installs {
+		if Fingerprint(install) == pin.Fingerprint {
+			return install, true
+		}
+	}
+	return Installation{}, false
+}
+
+// findPinFile returns the path of the nearest pin file in dir or its parents.
+func findPinFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, PinFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s file found", PinFileName)
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,43 @@
+package vswhere
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPinFile(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, Pin(root, Installation{InstanceID: "abc", InstallationVersion: "17.9.34607.119"}))
+
+	nested := filepath.Join(root, "src", "app")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	path, err := findPinFile(nested)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, PinFileName), path)
+
+	_, err = findPinFile(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestFindPinned(t *testing.T) {
+	install := Installation{
+		InstanceID:          "abc",
+		InstallationPath:    `C:\Program Files\Microsoft Visual Studio\2022\Community`,
+		InstallationVersion: "17.9.34607.119",
+		ProductID:           "Microsoft.VisualStudio.Product.Community",
+		ChannelID:           "VisualStudio.17.Release",
+	}
+	pin := pinFile{InstanceID: "old", Fingerprint: Fingerprint(install)}
+
+	found, ok := findPinned([]Installation{install}, pin)
+	require.True(t, ok)
+	require.Equal(t, "abc", found.InstanceID)
+
+	pin.Fingerprint = ""
+	_, ok = findPinned([]Installation{install}, pin)
+	require.False(t, ok)
+}
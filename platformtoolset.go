@@ -0,0 +1,85 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// platformToolset maps an MSBuild platform toolset to the range of MSVC 14.x
+// minor versions that implement it.
+type platformToolset struct {
+	name               string
+	minMinor, maxMinor int // [minMinor, maxMinor)
+}
+
+var platformToolsets = []platformToolset{
+	{name: "v140", minMinor: 0, maxMinor: 10},
+	{name: "v141", minMinor: 10, maxMinor: 20},
+	{name: "v142", minMinor: 20, maxMinor: 30},
+	{name: "v143", minMinor: 30, maxMinor: 50},
+	{name: "v145", minMinor: 50, maxMinor: 60},
+}
+
+// ToolsetForPlatformToolset returns the range of MSVC toolset versions that
+// implement an MSBuild platform toolset, using the same bracket syntax as
+// WithVersion. For example, "v142" returns "[14.20,14.30)".
+func ToolsetForPlatformToolset(name string) (string, error) {
+	for _, pt := range platformToolsets {
+		if pt.name == name {
+			return fmt.Sprintf("[14.%d,14.%d)", pt.minMinor, pt.maxMinor), nil
+		}
+	}
+	return "", fmt.Errorf("unknown platform toolset %q", name)
+}
+
+// SelectByPlatformToolset finds the newest installation that can build
+// projects targeting an MSBuild platform toolset (e.g., "v142"). Installations
+// are inspected for a matching MSVC toolset, so newer Visual Studio versions
+// with the toolset installed as a compatibility component (such as the v142
+// tools in Visual Studio 2022) are also selected. Installations are found
+// with WithIncludePackages to detect the VC140Component. Options can be
+// provided to further customize the search.
+func SelectByPlatformToolset(ctx context.Context, name string, options ...Option) (Installation, Toolset, error) {
+	return Default().SelectByPlatformToolset(ctx, name, options...)
+}
+
+// SelectByPlatformToolset finds the newest installation with a platform
+// toolset using the Finder.
+func (f *Finder) SelectByPlatformToolset(ctx context.Context, name string, options ...Option) (Installation, Toolset, error) {
+	if _, err := ToolsetForPlatformToolset(name); err != nil {
+		return Installation{}, Toolset{}, err
+	}
+
+	installs, err := f.Find(ctx, append(options, WithIncludePackages(true))...)
+	if err != nil {
+		return Installation{}, Toolset{}, err
+	}
+
+	var (
+		found       bool
+		bestInstall Installation
+		bestToolset Toolset
+	)
+	for _, install := range installs {
+		toolsets, err := Toolsets(install)
+		if err != nil {
+			return Installation{}, Toolset{}, err
+		}
+		for _, t := range toolsets {
+			if t.PlatformToolset() != name {
+				continue
+			}
+			if !found || version.Compare(install.InstallationVersion, bestInstall.InstallationVersion) > 0 {
+				found, bestInstall, bestToolset = true, install, t
+			}
+			// Toolsets are sorted newest first.
+			break
+		}
+	}
+	if !found {
+		return Installation{}, Toolset{}, fmt.Errorf("%w: none provide platform toolset %s", ErrNoInstallations, name)
+	}
+	return bestInstall, bestToolset, nil
+}
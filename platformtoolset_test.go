@@ -0,0 +1,75 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolsetForPlatformToolset(t *testing.T) {
+	r, err := ToolsetForPlatformToolset("v142")
+	require.NoError(t, err)
+	require.Equal(t, "[14.20,14.30)", r)
+
+	r, err = ToolsetForPlatformToolset("v143")
+	require.NoError(t, err)
+	require.Equal(t, "[14.30,14.50)", r)
+
+	_, err = ToolsetForPlatformToolset("v100")
+	require.Error(t, err)
+
+	for version, expect := range map[string]string{
+		"14.16.27023": "v141",
+		"14.29.30133": "v142",
+		"14.44.35207": "v143",
+		"14.50.35717": "v145",
+		"15.0":        "",
+	} {
+		require.Equal(t, expect, Toolset{Version: version}.PlatformToolset(), version)
+	}
+}
+
+func TestSelectByPlatformToolsetV140(t *testing.T) {
+	vs140 := t.TempDir()
+	defer func(old func() string) { vs140Dir = old }(vs140Dir)
+	vs140Dir = func() string { return vs140 }
+
+	vs2017, vs2019 := t.TempDir(), t.TempDir()
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "installationPath": "` + filepath.ToSlash(vs2019) + `", "installationVersion": "16.11.34931.43"},
+  {"instanceId": "b", "installationPath": "` + filepath.ToSlash(vs2017) + `", "installationVersion": "15.9.34407.89", "packages": [{"id": "` + VC140Component + `"}]}
+]`}
+	f := NewFinder(WithFinderRunner(r))
+
+	install, toolset, err := f.SelectByPlatformToolset(context.Background(), "v140")
+	require.NoError(t, err)
+	require.Equal(t, "b", install.InstanceID)
+	require.Equal(t, Toolset{Version: "14.0", Path: filepath.Join(vs140, "VC"), InstallationPath: vs2017}, toolset)
+	require.Equal(t, "v140", toolset.PlatformToolset())
+	require.Contains(t, r.args, "packages")
+
+	// Visual Studio 2015 being installed doesn't make its toolset usable from
+	// installations without the component.
+	mkfile(t, vs140, "VC", "bin", "cl.exe")
+	install, _, err = f.SelectByPlatformToolset(context.Background(), "v140")
+	require.NoError(t, err)
+	require.Equal(t, "b", install.InstanceID)
+
+	toolsets, err := Toolsets(Installation{InstallationPath: vs140, InstallationVersion: "14.0.25431.01"})
+	require.NoError(t, err)
+	require.Equal(t, []Toolset{{Version: "14.0", Path: filepath.Join(vs140, "VC"), InstallationPath: vs140}}, toolsets)
+
+	toolsets, err = Toolsets(Installation{InstallationPath: t.TempDir(), InstallationVersion: "14.0.25431.01"})
+	require.NoError(t, err)
+	require.Empty(t, toolsets)
+}
+
+func TestToolsetBinPathV140(t *testing.T) {
+	toolset := Toolset{Version: "14.0", Path: "VC"}
+	require.Equal(t, filepath.Join("VC", "bin"), toolset.BinPath(X86, X86))
+	require.Equal(t, filepath.Join("VC", "bin", "x86_amd64"), toolset.BinPath(X86, X64))
+	require.Equal(t, filepath.Join("VC", "bin", "amd64"), toolset.BinPath(X64, X64))
+	require.Equal(t, filepath.Join("VC", "bin", "amd64_arm"), toolset.BinPath(X64, ARM))
+}
@@ -0,0 +1,63 @@
+package vswhere
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Registry keys holding Visual Studio Installer settings. Policies set by
+// administrators override the values set by the installer.
+const (
+	policiesKey = `SOFTWARE\Policies\Microsoft\VisualStudio\Setup`
+	setupKey    = `SOFTWARE\Microsoft\VisualStudio\Setup`
+)
+
+// SetupPolicies are the Visual Studio Installer policies set by administrators,
+// which may relocate where Visual Studio is installed. Empty fields aren't set.
+type SetupPolicies struct {
+	// CachePath is the directory of the shared package cache.
+	CachePath string
+
+	// SharedInstallationPath is the directory where components shared by
+	// every installation, such as the Windows SDK, are installed.
+	SharedInstallationPath string
+}
+
+// Policies returns the installer policies set in
+// "HKLM\SOFTWARE\Policies\Microsoft\VisualStudio\Setup".
+func Policies() (SetupPolicies, error) {
+	return readSetupKey(policiesKey)
+}
+
+// PackageCachePath returns the directory where the Visual Studio Installer
+// caches packages shared by every installation on the machine. It is
+// "%ProgramData%\Microsoft\VisualStudio\Packages" unless relocated with the
+// CachePath policy or registry value.
+func PackageCachePath() (string, error) {
+	return setupPath(func(p SetupPolicies) string { return p.CachePath },
+		filepath.Join(os.Getenv("ProgramData"), "Microsoft", "VisualStudio", "Packages"))
+}
+
+// SharedInstallationPath returns the directory where the Visual Studio
+// Installer installs components shared by every installation. It is
+// "%ProgramFiles(x86)%\Microsoft Visual Studio\Shared" unless relocated with
+// the SharedInstallationPath policy or registry value.
+func SharedInstallationPath() (string, error) {
+	return setupPath(func(p SetupPolicies) string { return p.SharedInstallationPath },
+		filepath.Join(os.Getenv("ProgramFiles(x86)"), "Microsoft Visual Studio", "Shared"))
+}
+
+// setupPath returns the path selected by get from the policies key, then the
+// installer's own key, falling back to def if neither sets it.
+func setupPath(get func(SetupPolicies) string, def string) (string, error) {
+	for _, key := range []string{policiesKey, setupKey} {
+		p, err := readSetupKey(key)
+		if err != nil {
+			return "", err
+		}
+		if path := get(p); path != "" {
+			return path, nil
+		}
+	}
+	return def, nil
+}
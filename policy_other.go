@@ -0,0 +1,7 @@
+//+build !windows
+
+package vswhere
+
+func readSetupKey(key string) (SetupPolicies, error) {
+	return SetupPolicies{}, ErrUnsupportedPlatform
+}
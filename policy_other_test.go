@@ -0,0 +1,17 @@
+//+build !windows
+
+package vswhere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoliciesUnsupported(t *testing.T) {
+	_, err := PackageCachePath()
+	require.True(t, errors.Is(err, ErrUnsupportedPlatform))
+	_, err = Policies()
+	require.True(t, errors.Is(err, ErrUnsupportedPlatform))
+}
@@ -0,0 +1,26 @@
+//+build windows
+
+package vswhere
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// readSetupKey reads installer settings from key in the 32-bit registry view.
+// A missing key has no settings.
+func readSetupKey(key string) (SetupPolicies, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, key, registry.QUERY_VALUE|registry.WOW64_32KEY)
+	if err == registry.ErrNotExist {
+		return SetupPolicies{}, nil
+	} else if err != nil {
+		return SetupPolicies{}, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	defer k.Close()
+
+	var p SetupPolicies
+	p.CachePath, _, _ = k.GetStringValue("CachePath")
+	p.SharedInstallationPath, _, _ = k.GetStringValue("SharedInstallationPath")
+	return p, nil
+}
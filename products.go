@@ -0,0 +1,61 @@
+package vswhere
+
+import "context"
+
+// Product IDs of Visual Studio editions.
+const (
+	ProductCommunity    = "Microsoft.VisualStudio.Product.Community"
+	ProductProfessional = "Microsoft.VisualStudio.Product.Professional"
+	ProductEnterprise   = "Microsoft.VisualStudio.Product.Enterprise"
+	ProductBuildTools   = "Microsoft.VisualStudio.Product.BuildTools"
+
+	ProductTeamExplorer   = "Microsoft.VisualStudio.Product.TeamExplorer"
+	ProductTestAgent      = "Microsoft.VisualStudio.Product.TestAgent"
+	ProductTestController = "Microsoft.VisualStudio.Product.TestController"
+)
+
+// SecondaryProducts are the products which have no development tools of their
+// own. Like Build Tools, vswhere doesn't search them unless requested with
+// WithProducts. Their installations have a reduced layout: they don't include
+// MSBuild or the C++ toolset, so helpers such as MSBuildPath return an error
+// for them, and only Team Explorer includes devenv.exe.
+var SecondaryProducts = []string{ProductTeamExplorer, ProductTestAgent, ProductTestController}
+
+// FindProduct finds installations of the product ID, including products
+// vswhere doesn't search by default such as ProductTestAgent. Options can be
+// provided to further customize the search; WithProducts is overridden.
+func FindProduct(ctx context.Context, product string, options ...Option) ([]Installation, error) {
+	return Default().FindProduct(ctx, product, options...)
+}
+
+// FindProduct finds installations of the product ID using the Finder.
+func (f *Finder) FindProduct(ctx context.Context, product string, options ...Option) ([]Installation, error) {
+	return f.Find(ctx, append(options, WithProducts([]string{product}))...)
+}
+
+// IsSecondary reports whether the installation is of one of the
+// SecondaryProducts.
+func (i Installation) IsSecondary() bool {
+	return containsFold(SecondaryProducts, i.ProductID)
+}
+
+// editionNames are the English names of each product in display names.
+var editionNames = map[string]string{
+	ProductCommunity:      "Community",
+	ProductProfessional:   "Professional",
+	ProductEnterprise:     "Enterprise",
+	ProductBuildTools:     "Build Tools",
+	ProductTeamExplorer:   "Team Explorer",
+	ProductTestAgent:      "Test Agent",
+	ProductTestController: "Test Controller",
+}
+
+// invariantName returns the English display name of install, or an empty
+// string if it can't be determined.
+func invariantName(install Installation) string {
+	edition, ok := editionNames[install.ProductID]
+	if !ok || install.Catalog.ProductName == "" || install.Catalog.ProductLineVersion == "" {
+		return ""
+	}
+	return install.Catalog.ProductName + " " + edition + " " + install.Catalog.ProductLineVersion
+}
@@ -0,0 +1,25 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecondaryProducts(t *testing.T) {
+	so := newSearchOptions([]Option{WithProducts(SecondaryProducts)})
+	for _, id := range SecondaryProducts {
+		install := Installation{ProductID: id, IsComplete: true, IsLaunchable: true}
+		require.True(t, install.IsSecondary())
+
+		ok, err := so.matches(install)
+		require.NoError(t, err)
+		require.True(t, ok, id)
+
+		// Secondary products aren't searched by default.
+		ok, err = (&searchOptions{}).matches(install)
+		require.NoError(t, err)
+		require.False(t, ok, id)
+	}
+	require.False(t, Installation{ProductID: ProductBuildTools}.IsSecondary())
+}
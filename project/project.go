@@ -0,0 +1,116 @@
+// Package project selects a Visual Studio installation capable of building a
+// solution (.sln) or C++ project (.vcxproj).
+package project
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Solution holds the build-relevant settings of a solution file.
+type Solution struct {
+	// MinimumVisualStudioVersion is the oldest version of Visual Studio that
+	// can open the solution.
+	MinimumVisualStudioVersion string
+
+	// Projects holds the paths of the C++ projects (.vcxproj) in the
+	// solution.
+	Projects []string
+}
+
+var (
+	slnVersionRegex = regexp.MustCompile(`^\s*MinimumVisualStudioVersion\s*=\s*(\S+)`)
+	slnProjectRegex = regexp.MustCompile(`^Project\("[^"]*"\)\s*=\s*"[^"]*"\s*,\s*"([^"]+)"`)
+)
+
+// ParseSolution parses the solution file at path. Project paths are resolved
+// relative to the solution's directory.
+func ParseSolution(path string) (Solution, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Solution{}, err
+	}
+	defer f.Close()
+
+	var (
+		sln Solution
+		dir = filepath.Dir(path)
+	)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if m := slnVersionRegex.FindStringSubmatch(line); m != nil {
+			sln.MinimumVisualStudioVersion = m[1]
+		} else if m := slnProjectRegex.FindStringSubmatch(line); m != nil {
+			projPath := filepath.FromSlash(strings.ReplaceAll(m[1], `\`, "/"))
+			if strings.EqualFold(filepath.Ext(projPath), ".vcxproj") {
+				sln.Projects = append(sln.Projects, filepath.Join(dir, projPath))
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return Solution{}, fmt.Errorf("failed to read solution %s: %w", path, err)
+	}
+	return sln, nil
+}
+
+// Project holds the build-relevant settings of a C++ project file.
+type Project struct {
+	// PlatformToolsets are the distinct platform toolsets (e.g., "v143") used
+	// by the project's configurations.
+	PlatformToolsets []string
+
+	// WindowsTargetPlatformVersion is the Windows SDK targeted by the project.
+	// "10.0" targets the newest installed Windows 10 or later SDK.
+	WindowsTargetPlatformVersion string
+}
+
+type vcxproj struct {
+	PropertyGroups []struct {
+		PlatformToolset              []string `xml:"PlatformToolset"`
+		WindowsTargetPlatformVersion []string `xml:"WindowsTargetPlatformVersion"`
+	} `xml:"PropertyGroup"`
+}
+
+// ParseProject parses the C++ project file at path. Values referring to
+// MSBuild properties (e.g., "$(DefaultPlatformToolset)") can't be resolved
+// and are ignored.
+func ParseProject(path string) (Project, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Project{}, err
+	}
+	defer f.Close()
+
+	var raw vcxproj
+	if err := xml.NewDecoder(f).Decode(&raw); err != nil {
+		return Project{}, fmt.Errorf("failed to parse project %s: %w", path, err)
+	}
+
+	var (
+		proj Project
+		seen = make(map[string]bool)
+	)
+	for _, pg := range raw.PropertyGroups {
+		for _, pt := range pg.PlatformToolset {
+			pt = strings.TrimSpace(pt)
+			if pt == "" || strings.Contains(pt, "$(") || seen[pt] {
+				continue
+			}
+			seen[pt] = true
+			proj.PlatformToolsets = append(proj.PlatformToolsets, pt)
+		}
+		for _, v := range pg.WindowsTargetPlatformVersion {
+			v = strings.TrimSpace(v)
+			if v != "" && !strings.Contains(v, "$(") {
+				proj.WindowsTargetPlatformVersion = v
+			}
+		}
+	}
+	return proj, nil
+}
@@ -0,0 +1,95 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rfratto/vswhere"
+	"github.com/stretchr/testify/require"
+)
+
+const testSolution = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio Version 17
+VisualStudioVersion = 17.5.33516.290
+MinimumVisualStudioVersion = 10.0.40219.1
+Project("{8BC9CEB8-8B4A-11D0-8D11-00A0C91BC942}") = "app", "app\app.vcxproj", "{11111111-1111-1111-1111-111111111111}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "tool", "tool\tool.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+`
+
+const testProject = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup Label="Globals">
+    <WindowsTargetPlatformVersion>10.0.19041.0</WindowsTargetPlatformVersion>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)|$(Platform)'=='Debug|x64'" Label="Configuration">
+    <PlatformToolset>v142</PlatformToolset>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)|$(Platform)'=='Release|x64'" Label="Configuration">
+    <PlatformToolset>v142</PlatformToolset>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)|$(Platform)'=='Release|ARM64'" Label="Configuration">
+    <PlatformToolset>$(DefaultPlatformToolset)</PlatformToolset>
+  </PropertyGroup>
+</Project>
+`
+
+func TestRequirementsFor(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, testSolution, dir, "app.sln")
+	writeFile(t, testProject, dir, "app", "app.vcxproj")
+
+	sln, err := ParseSolution(filepath.Join(dir, "app.sln"))
+	require.NoError(t, err)
+	require.Equal(t, "10.0.40219.1", sln.MinimumVisualStudioVersion)
+	require.Equal(t, []string{filepath.Join(dir, "app", "app.vcxproj")}, sln.Projects)
+
+	req, err := RequirementsFor(filepath.Join(dir, "app.sln"))
+	require.NoError(t, err)
+	require.Equal(t, Requirements{
+		MinimumVisualStudioVersion: "10.0.40219.1",
+		PlatformToolsets:           []string{"v142"},
+		WindowsSDKs:                []string{"10.0.19041.0"},
+	}, req)
+}
+
+func TestChoose(t *testing.T) {
+	vs2019 := vswhere.Installation{InstanceID: "a", InstallationVersion: "16.11.34601.136", InstallationPath: t.TempDir()}
+	writeFile(t, "", vs2019.InstallationPath, "VC", "Tools", "MSVC", "14.29.30133", "bin", "cl.exe")
+	vs2022 := vswhere.Installation{InstanceID: "b", InstallationVersion: "17.9.34607.119", DisplayName: "VS 2022", InstallationPath: t.TempDir()}
+	writeFile(t, "", vs2022.InstallationPath, "VC", "Tools", "MSVC", "14.39.33519", "bin", "cl.exe")
+
+	installs := []vswhere.Installation{vs2019, vs2022}
+	sdks := []string{"10.0.19041.0"}
+
+	install, err := choose(installs, Requirements{PlatformToolsets: []string{"v142"}, WindowsSDKs: []string{"10.0"}}, sdks)
+	require.NoError(t, err)
+	require.Equal(t, "a", install.InstanceID)
+
+	install, err = choose(installs, Requirements{PlatformToolsets: []string{"v143"}}, sdks)
+	require.NoError(t, err)
+	require.Equal(t, "b", install.InstanceID)
+
+	_, err = choose(installs, Requirements{PlatformToolsets: []string{"v141"}, WindowsSDKs: []string{"10.0.22621.0"}}, sdks)
+	var missing *MissingError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, "b", missing.Installation.InstanceID)
+	require.Equal(t, []string{"v141"}, missing.PlatformToolsets)
+	require.Equal(t, []string{"10.0.22621.0"}, missing.WindowsSDKs)
+	require.Equal(t, []string{
+		"Microsoft.VisualStudio.Component.VC.v141.x86.x64",
+		"Microsoft.VisualStudio.Component.Windows11SDK.22621",
+	}, missing.Components)
+	require.Equal(t, "VS 2022 cannot build the project: missing platform toolsets v141; missing Windows SDKs 10.0.22621.0; add components Microsoft.VisualStudio.Component.VC.v141.x86.x64, Microsoft.VisualStudio.Component.Windows11SDK.22621", err.Error())
+}
+
+func writeFile(t *testing.T, contents string, elem ...string) {
+	t.Helper()
+	path := filepath.Join(elem...)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+}
@@ -0,0 +1,247 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rfratto/vswhere"
+	"github.com/rfratto/vswhere/internal/version"
+	"github.com/rfratto/vswhere/winsdk"
+)
+
+// Requirements are what an installation must provide to build a solution or
+// project.
+type Requirements struct {
+	// MinimumVisualStudioVersion is the oldest version of Visual Studio that
+	// can open the solution. Empty for no requirement.
+	MinimumVisualStudioVersion string
+
+	// PlatformToolsets holds the platform toolsets (e.g., "v142") used by the
+	// projects.
+	PlatformToolsets []string
+
+	// WindowsSDKs holds the Windows SDK versions targeted by the projects.
+	// "10.0" is satisfied by any Windows 10 or later SDK.
+	WindowsSDKs []string
+}
+
+// RequirementsFor returns the requirements of the solution (.sln) or C++
+// project (.vcxproj) at path. For solutions, the requirements of all C++
+// projects in the solution are combined.
+func RequirementsFor(path string) (Requirements, error) {
+	var (
+		req      Requirements
+		projects []string
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sln":
+		sln, err := ParseSolution(path)
+		if err != nil {
+			return Requirements{}, err
+		}
+		req.MinimumVisualStudioVersion = sln.MinimumVisualStudioVersion
+		projects = sln.Projects
+	case ".vcxproj":
+		projects = []string{path}
+	default:
+		return Requirements{}, fmt.Errorf("unsupported project file %s", path)
+	}
+
+	for _, p := range projects {
+		proj, err := ParseProject(p)
+		if err != nil {
+			return Requirements{}, err
+		}
+		for _, pt := range proj.PlatformToolsets {
+			req.PlatformToolsets = appendUnique(req.PlatformToolsets, pt)
+		}
+		if v := proj.WindowsTargetPlatformVersion; v != "" {
+			req.WindowsSDKs = appendUnique(req.WindowsSDKs, v)
+		}
+	}
+	return req, nil
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, e := range list {
+		if e == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+// MissingError is returned by Select when no installation can build a
+// project. It describes what the newest installation is missing.
+type MissingError struct {
+	// Installation is the newest installation found, which is the one
+	// recommended to modify. It is the zero value if no installations exist.
+	Installation vswhere.Installation
+
+	// PlatformToolsets are the required platform toolsets the installation
+	// doesn't have.
+	PlatformToolsets []string
+
+	// WindowsSDKs are the required Windows SDK versions that aren't
+	// installed.
+	WindowsSDKs []string
+
+	// Components are the IDs of the components to add to the installation to
+	// satisfy the requirements.
+	Components []string
+}
+
+// Error implements error.
+func (e *MissingError) Error() string {
+	if e.Installation.InstanceID == "" {
+		return "no Visual Studio installations found"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s cannot build the project:", e.Installation.DisplayName)
+	if len(e.PlatformToolsets) > 0 {
+		fmt.Fprintf(&sb, " missing platform toolsets %s;", strings.Join(e.PlatformToolsets, ", "))
+	}
+	if len(e.WindowsSDKs) > 0 {
+		fmt.Fprintf(&sb, " missing Windows SDKs %s;", strings.Join(e.WindowsSDKs, ", "))
+	}
+	if len(e.Components) > 0 {
+		fmt.Fprintf(&sb, " add components %s", strings.Join(e.Components, ", "))
+	}
+	return strings.TrimSuffix(sb.String(), ";")
+}
+
+// Select finds the newest installation able to build the solution or project
+// at path. Options can be provided to further customize the search. If no
+// installation qualifies, a *MissingError is returned.
+func Select(ctx context.Context, path string, options ...vswhere.Option) (vswhere.Installation, error) {
+	return SelectWithFinder(ctx, vswhere.Default(), path, options...)
+}
+
+// SelectWithFinder is like Select but finds installations with f.
+func SelectWithFinder(ctx context.Context, f *vswhere.Finder, path string, options ...vswhere.Option) (vswhere.Installation, error) {
+	req, err := RequirementsFor(path)
+	if err != nil {
+		return vswhere.Installation{}, err
+	}
+	// Packages are needed to detect the Visual Studio 2015 toolset.
+	installs, err := f.Find(ctx, append(options, vswhere.WithIncludePackages(true))...)
+	if err != nil {
+		return vswhere.Installation{}, err
+	}
+	// A machine without a Windows SDK simply fails the SDK requirements.
+	sdks, _ := winsdk.Versions()
+	return choose(installs, req, sdks)
+}
+
+// choose returns the newest installation satisfying req.
+func choose(installs []vswhere.Installation, req Requirements, sdks []string) (vswhere.Installation, error) {
+	var missingSDKs []string
+	for _, v := range req.WindowsSDKs {
+		if !hasSDK(sdks, v) {
+			missingSDKs = append(missingSDKs, v)
+		}
+	}
+
+	var best, newest *MissingError
+	for _, install := range installs {
+		if req.MinimumVisualStudioVersion != "" && major(install.InstallationVersion) < major(req.MinimumVisualStudioVersion) {
+			continue
+		}
+
+		toolsets, err := vswhere.Toolsets(install)
+		if err != nil {
+			return vswhere.Installation{}, err
+		}
+		m := &MissingError{Installation: install, WindowsSDKs: missingSDKs}
+		for _, pt := range req.PlatformToolsets {
+			if !hasPlatformToolset(toolsets, pt) {
+				m.PlatformToolsets = append(m.PlatformToolsets, pt)
+			}
+		}
+
+		if newest == nil || isNewer(install, newest.Installation) {
+			newest = m
+		}
+		if len(m.PlatformToolsets) == 0 && (best == nil || isNewer(install, best.Installation)) {
+			best = m
+		}
+	}
+
+	switch {
+	case newest == nil:
+		return vswhere.Installation{}, &MissingError{WindowsSDKs: missingSDKs}
+	case best != nil && len(missingSDKs) == 0:
+		return best.Installation, nil
+	}
+
+	vsMajor := major(newest.Installation.InstallationVersion)
+	for _, pt := range newest.PlatformToolsets {
+		if id := toolsetComponent(pt, vsMajor); id != "" {
+			newest.Components = append(newest.Components, id)
+		}
+	}
+	for _, v := range newest.WindowsSDKs {
+		if id, err := vswhere.WindowsSDKComponent(v); err == nil {
+			newest.Components = append(newest.Components, id)
+		}
+	}
+	return vswhere.Installation{}, newest
+}
+
+func isNewer(a, b vswhere.Installation) bool {
+	return version.Compare(a.InstallationVersion, b.InstallationVersion) > 0
+}
+
+func hasSDK(sdks []string, want string) bool {
+	for _, v := range sdks {
+		if want == "10.0" || version.Compare(v, want) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPlatformToolset(toolsets []vswhere.Toolset, want string) bool {
+	for _, t := range toolsets {
+		if t.PlatformToolset() == want {
+			return true
+		}
+	}
+	return false
+}
+
+func major(v string) int {
+	n, _ := strconv.Atoi(strings.SplitN(v, ".", 2)[0])
+	return n
+}
+
+// nativeToolsets maps platform toolsets to the Visual Studio major version
+// they shipped with.
+var nativeToolsets = map[string]int{
+	"v141": 15,
+	"v142": 16,
+	"v143": 17,
+	"v145": 18,
+}
+
+// toolsetComponent returns the component ID providing a platform toolset in
+// a Visual Studio of the given major version.
+func toolsetComponent(pt string, vsMajor int) string {
+	native, ok := nativeToolsets[pt]
+	switch {
+	case pt == "v140" && vsMajor >= 15:
+		return vswhere.VC140Component
+	case !ok || native > vsMajor:
+		return ""
+	case native == vsMajor:
+		return "Microsoft.VisualStudio.Component.VC.Tools.x86.x64"
+	case pt == "v141":
+		return "Microsoft.VisualStudio.Component.VC.v141.x86.x64"
+	default:
+		return "Microsoft.VisualStudio.ComponentGroup.VC.Tools." + strings.TrimPrefix(pt, "v") + ".x86.x64"
+	}
+}
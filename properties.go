@@ -0,0 +1,188 @@
+package vswhere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GetProperties finds installations like Find, but only returns the named
+// properties of each installation, in the same order. Property names are the
+// JSON names used by vswhere, matched case-insensitively, and nested
+// properties are separated by a period (e.g.,
+// "catalog.productDisplayVersion"). Properties that are empty, unknown, or not
+// scalar values are omitted from the returned maps.
+func GetProperties(ctx context.Context, props []string, options ...Option) ([]map[string]string, error) {
+	return Default().GetProperties(ctx, props, options...)
+}
+
+// GetProperties finds installations like Find using the Finder, but only
+// returns the named properties.
+func (f *Finder) GetProperties(ctx context.Context, props []string, options ...Option) ([]map[string]string, error) {
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]string, 0, len(installs))
+	for _, install := range installs {
+		bb, err := json.Marshal(install)
+		if err != nil {
+			return nil, err
+		}
+		var obj propertyObject
+		if err := json.Unmarshal(bb, &obj); err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string, len(props))
+		for _, prop := range props {
+			if v, ok := lookupProperty(obj, prop); ok && v != "" {
+				values[prop] = v
+			}
+		}
+		res = append(res, values)
+	}
+	return res, nil
+}
+
+// FindProperty finds installations like Find, but only returns the value of
+// property for each installation, using vswhere's -property flag to avoid
+// decoding the full JSON document. Nested properties are separated by a period
+// (e.g., "catalog.productDisplayVersion"). Installations without the property
+// are skipped.
+func FindProperty(ctx context.Context, property string, options ...Option) ([]string, error) {
+	return Default().FindProperty(ctx, property, options...)
+}
+
+// FindProperty finds installations like Find, but only returns the value of
+// property for each installation. If vswhere isn't the Finder's first backend
+// or isn't installed, the property is read from the JSON of the next
+// available backend instead. Transformers aren't applied.
+func (f *Finder) FindProperty(ctx context.Context, property string, options ...Option) ([]string, error) {
+	searchOpts, err := f.newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	args, err := searchOpts.args()
+	if err != nil {
+		return nil, err
+	}
+
+	if values, ok, err := f.runValues(ctx, &searchOpts, args, "-property", property); ok {
+		return values, err
+	}
+
+	out, err := f.runOutput(ctx, &searchOpts, args)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := decodeProperties(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, obj := range objects {
+		if v, ok := lookupProperty(obj, property); ok {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// runValues runs vswhere with flags and the value format, returning the
+// values it printed. ok is false if vswhere isn't the Finder's first backend
+// or isn't installed, in which case the caller should fall back to the JSON
+// of the other backends.
+func (f *Finder) runValues(ctx context.Context, so *searchOptions, args []string, flags ...string) (values []string, ok bool, err error) {
+	backends := f.backends
+	if backends == nil {
+		backends = DefaultBackends
+	}
+	if len(backends) == 0 || backends[0] != BackendVswhere {
+		return nil, false, nil
+	}
+	if so.installPath != "" && so.path == "" {
+		// Values can't be filtered by path after the query.
+		return nil, false, nil
+	}
+
+	// Replace the trailing "-format json".
+	valueArgs := append(append(args[:len(args)-2:len(args)-2], flags...), "-format", "value")
+	out, err := f.record(ctx, so, valueArgs, func(ctx context.Context, info *RunInfo) ([]byte, error) {
+		return f.execVswhere(ctx, so, valueArgs, info)
+	})
+	if isUnavailable(err) || errors.Is(err, ErrUnsupportedFlag) {
+		// Fall back to the JSON output, which every version supports.
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+	return parseValues(out), true, nil
+}
+
+// parseValues parses the output of vswhere's value format, which prints one
+// value per line.
+func parseValues(out []byte) []string {
+	var values []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			values = append(values, line)
+		}
+	}
+	return values
+}
+
+// propertyObject is a JSON object whose values are only decoded when looked
+// up, so projecting a few properties doesn't decode the whole document.
+type propertyObject map[string]json.RawMessage
+
+func decodeProperties(out []byte) ([]propertyObject, error) {
+	var objects []propertyObject
+	if err := json.Unmarshal(out, &objects); err != nil {
+		return nil, fmt.Errorf("failed parsing output of vswhere: %w", err)
+	}
+	return objects, nil
+}
+
+func lookupProperty(obj propertyObject, prop string) (string, bool) {
+	var raw json.RawMessage
+	names := strings.Split(prop, ".")
+	for i, name := range names {
+		raw = nil
+		for k, v := range obj {
+			if strings.EqualFold(k, name) {
+				raw = v
+				break
+			}
+		}
+		if raw == nil {
+			return "", false
+		}
+		if i < len(names)-1 {
+			obj = nil
+			if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+				return "", false
+			}
+		}
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool, json.Number:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,70 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProperty(t *testing.T) {
+	objects, err := decodeProperties([]byte(`[{
+  "installationPath": "C:\\VS",
+  "isComplete": true,
+  "catalog": {"productDisplayVersion": "17.9.2"}
+}]`))
+	require.NoError(t, err)
+	obj := objects[0]
+
+	v, ok := lookupProperty(obj, "installationPath")
+	require.True(t, ok)
+	require.Equal(t, `C:\VS`, v)
+
+	v, ok = lookupProperty(obj, "Catalog.ProductDisplayVersion")
+	require.True(t, ok)
+	require.Equal(t, "17.9.2", v)
+
+	v, ok = lookupProperty(obj, "isComplete")
+	require.True(t, ok)
+	require.Equal(t, "true", v)
+
+	_, ok = lookupProperty(obj, "catalog")
+	require.False(t, ok)
+	_, ok = lookupProperty(obj, "missing")
+	require.False(t, ok)
+	_, ok = lookupProperty(obj, "installationPath.missing")
+	require.False(t, ok)
+}
+
+func TestFindProperty(t *testing.T) {
+	r := &fakeRunner{stdout: "C:\\VS\\2019\r\nC:\\VS\\2022\r\n"}
+	f := NewFinder(WithFinderRunner(r))
+
+	values, err := f.FindProperty(context.Background(), "installationPath", WithLatest(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"-latest", "-property", "installationPath", "-format", "value"}, r.args)
+	require.Equal(t, []string{`C:\VS\2019`, `C:\VS\2022`}, values)
+
+	// Backends without a value format fall back to JSON.
+	dir := t.TempDir()
+	writeState(t, dir, `{"installationPath": "C:\\VS", "product": {"id": "Microsoft.VisualStudio.Product.Community"}}`, "abc")
+	values, err = NewFinder(WithFinderOffline(dir)).FindProperty(context.Background(), "InstallationPath")
+	require.NoError(t, err)
+	require.Equal(t, []string{`C:\VS`}, values)
+}
+
+func TestGetProperties(t *testing.T) {
+	r := &fakeRunner{stdout: `[{"instanceId": "a", "installationPath": "C:\\VS", "catalog": {"productDisplayVersion": "17.9.2"}}]`}
+	values, err := NewFinder(WithFinderRunner(r)).GetProperties(context.Background(), []string{"instanceId", "catalog.productDisplayVersion", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"-format", "json"}, r.args)
+	require.Equal(t, []map[string]string{{"instanceId": "a", "catalog.productDisplayVersion": "17.9.2"}}, values)
+
+	// A single property is projected the same way, with one map per
+	// installation even if the property is empty.
+	r = &fakeRunner{stdout: `[{"instanceId": "a", "installationPath": "C:\\VS"}, {"instanceId": "b"}]`}
+	values, err = NewFinder(WithFinderRunner(r)).GetProperties(context.Background(), []string{"installationPath"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"-format", "json"}, r.args)
+	require.Equal(t, []map[string]string{{"installationPath": `C:\VS`}, {}}, values)
+}
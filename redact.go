@@ -0,0 +1,51 @@
+package vswhere
+
+import "regexp"
+
+// Clone returns a deep copy of the installation.
+func (i Installation) Clone() Installation {
+	c := i
+	if i.Packages != nil {
+		c.Packages = append([]Package(nil), i.Packages...)
+	}
+	if i.Errors.FailedPackages != nil {
+		c.Errors.FailedPackages = append([]FailedPackage(nil), i.Errors.FailedPackages...)
+	}
+	if i.Errors.SkippedPackages != nil {
+		c.Errors.SkippedPackages = append([]Package(nil), i.Errors.SkippedPackages...)
+	}
+	return c
+}
+
+// userProfileRegex matches the user name in a path beneath C:\Users.
+var userProfileRegex = regexp.MustCompile(`(?i)(\\Users\\)[^\\]+`)
+
+// Redacted returns a copy of the installation with user-identifying
+// information removed so it can be shared in bug reports. The nickname and
+// campaign ID are cleared, and user names in paths beneath a Users directory,
+// including the logs of failed packages, are replaced with "REDACTED".
+func (i Installation) Redacted() Installation {
+	r := i.Clone()
+	r.Properties.Nickname = ""
+	r.Properties.CampaignID = ""
+
+	paths := []*string{
+		&r.InstallationPath,
+		&r.ProductPath,
+		&r.EnginePath,
+		&r.Properties.SetupEngineFilePath,
+	}
+	for j := range r.Errors.FailedPackages {
+		paths = append(paths, &r.Errors.FailedPackages[j].LogFilePath)
+	}
+	for _, path := range paths {
+		*path = redactPath(*path)
+	}
+	return r
+}
+
+// redactPath replaces user names in s beneath a Users directory with
+// "REDACTED".
+func redactPath(s string) string {
+	return userProfileRegex.ReplaceAllString(s, "${1}REDACTED")
+}
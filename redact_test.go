@@ -0,0 +1,56 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedacted(t *testing.T) {
+	i := Installation{
+		InstanceID:       "abc",
+		InstallationPath: `C:\Users\jdoe\VS\2019`,
+		ProductPath:      `C:\Program Files\VS\devenv.exe`,
+		Properties: Properties{
+			CampaignID: "1234",
+			Nickname:   "jdoe's VS",
+		},
+		Errors: Errors{
+			FailedPackages: []FailedPackage{{LogFilePath: `C:\Users\jdoe\AppData\Local\Temp\dd_setup_20240101_001.log`}},
+		},
+	}
+
+	r := i.Redacted()
+	require.Equal(t, `C:\Users\REDACTED\AppData\Local\Temp\dd_setup_20240101_001.log`, r.Errors.FailedPackages[0].LogFilePath)
+	require.Equal(t, `C:\Users\REDACTED\VS\2019`, r.InstallationPath)
+	require.Equal(t, `C:\Program Files\VS\devenv.exe`, r.ProductPath)
+	require.Empty(t, r.Properties.CampaignID)
+	require.Empty(t, r.Properties.Nickname)
+	require.Equal(t, "abc", r.InstanceID)
+
+	// The original installation is left unmodified.
+	require.Equal(t, `C:\Users\jdoe\VS\2019`, i.InstallationPath)
+	require.Equal(t, "jdoe's VS", i.Properties.Nickname)
+	require.Equal(t, `C:\Users\jdoe\AppData\Local\Temp\dd_setup_20240101_001.log`, i.Errors.FailedPackages[0].LogFilePath)
+}
+
+func TestClone(t *testing.T) {
+	i := Installation{
+		InstanceID: "abc",
+		Packages:   []Package{{ID: "Microsoft.VisualStudio.Component.VC.Tools.x86.x64"}},
+		Errors: Errors{
+			FailedPackages:  []FailedPackage{{ID: "Microsoft.VisualStudio.Foo", LogFilePath: `C:\Users\jdoe\AppData\Local\Temp\dd_setup.log`}},
+			SkippedPackages: []Package{{ID: "Microsoft.VisualStudio.Bar"}},
+		},
+	}
+
+	c := i.Clone()
+	require.Equal(t, i, c)
+
+	c.Packages[0].ID = "changed"
+	c.Errors.FailedPackages[0].LogFilePath = "changed"
+	c.Errors.SkippedPackages[0].ID = "changed"
+	require.Equal(t, "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", i.Packages[0].ID)
+	require.Equal(t, `C:\Users\jdoe\AppData\Local\Temp\dd_setup.log`, i.Errors.FailedPackages[0].LogFilePath)
+	require.Equal(t, "Microsoft.VisualStudio.Bar", i.Errors.SkippedPackages[0].ID)
+}
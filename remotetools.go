@@ -0,0 +1,76 @@
+package vswhere
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RemoteTools is a standalone installation of the Remote Tools for Visual
+// Studio, which provide the remote debugger (msvsmon.exe) on machines
+// without Visual Studio. Remote Tools aren't Visual Studio instances and
+// aren't found by Find.
+type RemoteTools struct {
+	DisplayName      string
+	Version          string
+	InstallationPath string
+
+	// Msvsmon holds the path to msvsmon.exe for each architecture installed.
+	Msvsmon map[Arch]string
+}
+
+// uninstallEntry is a program registered in the Uninstall registry key.
+type uninstallEntry struct {
+	DisplayName     string
+	DisplayVersion  string
+	InstallLocation string
+}
+
+// FindRemoteTools finds installations of the Remote Tools for Visual Studio
+// from the programs registered with Windows.
+func FindRemoteTools() ([]RemoteTools, error) {
+	entries, err := uninstallEntries()
+	if err != nil {
+		return nil, err
+	}
+	return remoteTools(entries, os.Getenv("ProgramFiles")), nil
+}
+
+func remoteTools(entries []uninstallEntry, programFiles string) []RemoteTools {
+	var res []RemoteTools
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if !strings.HasPrefix(strings.ToLower(e.DisplayName), "remote tools for visual studio") {
+			continue
+		}
+
+		path := e.InstallLocation
+		if path == "" {
+			// The installer doesn't always record its location, but always
+			// installs to the directory named after the major version.
+			major := strings.SplitN(e.DisplayVersion, ".", 2)[0]
+			path = filepath.Join(programFiles, "Microsoft Visual Studio "+major+".0")
+		}
+		key := strings.ToLower(filepath.Clean(path))
+		if seen[key] {
+			// Remote Tools register both a bundle and its packages.
+			continue
+		}
+		seen[key] = true
+
+		rt := RemoteTools{
+			DisplayName:      e.DisplayName,
+			Version:          e.DisplayVersion,
+			InstallationPath: path,
+			Msvsmon:          make(map[Arch]string),
+		}
+		for _, arch := range []Arch{X86, X64, ARM64} {
+			msvsmon := filepath.Join(path, "Common7", "IDE", "Remote Debugger", string(arch), "msvsmon.exe")
+			if _, err := os.Stat(msvsmon); err == nil {
+				rt.Msvsmon[arch] = msvsmon
+			}
+		}
+		res = append(res, rt)
+	}
+	return res
+}
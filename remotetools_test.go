@@ -0,0 +1,30 @@
+package vswhere
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteTools(t *testing.T) {
+	programFiles := t.TempDir()
+	path := filepath.Join(programFiles, "Microsoft Visual Studio 17.0")
+	msvsmon := filepath.Join(path, "Common7", "IDE", "Remote Debugger", "x64", "msvsmon.exe")
+	require.NoError(t, os.MkdirAll(filepath.Dir(msvsmon), 0755))
+	require.NoError(t, ioutil.WriteFile(msvsmon, nil, 0644))
+
+	rts := remoteTools([]uninstallEntry{
+		{DisplayName: "Microsoft Visual C++ 2015-2022 Redistributable (x64)", DisplayVersion: "14.38.33130.0"},
+		{DisplayName: "Remote Tools for Visual Studio 2022", DisplayVersion: "17.8.34322.80"},
+		{DisplayName: "Remote Tools for Visual Studio 2022", DisplayVersion: "17.8.34322.80", InstallLocation: path},
+	}, programFiles)
+	require.Equal(t, []RemoteTools{{
+		DisplayName:      "Remote Tools for Visual Studio 2022",
+		Version:          "17.8.34322.80",
+		InstallationPath: path,
+		Msvsmon:          map[Arch]string{X64: msvsmon},
+	}}, rts)
+}
@@ -0,0 +1,214 @@
+package vswhere
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rfratto/vswhere/winsdk"
+)
+
+// ReportFormat is the output format of a report.
+type ReportFormat int
+
+// Supported report formats.
+const (
+	ReportMarkdown ReportFormat = iota
+	ReportHTML
+)
+
+// ReportOptions customizes a report generated by Report.
+type ReportOptions struct {
+	// Format of the report. Defaults to Markdown.
+	Format ReportFormat
+
+	// Title of the report. Defaults to "Visual Studio Report".
+	Title string
+}
+
+type reportData struct {
+	Title     string
+	Generated string
+	Machine   *Machine
+	Installs  []Installation
+	Inventory []reportInventory
+	SDKs      []string
+}
+
+// reportInventory lists what an installation can build with.
+type reportInventory struct {
+	InstanceID string
+	Toolsets   string
+	Workloads  string
+}
+
+// Report renders a machine report describing the host, installations, their
+// health, MSVC toolsets, and workloads, and the Windows SDKs installed on the
+// machine. The report is intended to be attached to CI artifacts and support
+// tickets. Workloads are only listed for installations found with
+// WithIncludePackages.
+func Report(installs []Installation, opts ReportOptions) (string, error) {
+	data := reportData{
+		Title:     opts.Title,
+		Generated: time.Now().UTC().Format(time.RFC3339),
+		Installs:  installs,
+	}
+	if data.Title == "" {
+		data.Title = "Visual Studio Report"
+	}
+	for _, install := range installs {
+		data.Inventory = append(data.Inventory, inventoryOf(install))
+	}
+	if m, err := MachineInfo(); err == nil {
+		data.Machine = &m
+	}
+	// A missing Windows SDK is reported as such rather than failing the report.
+	data.SDKs, _ = winsdk.Versions()
+
+	var buf bytes.Buffer
+	switch opts.Format {
+	case ReportMarkdown:
+		if err := markdownReport.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render report: %w", err)
+		}
+	case ReportHTML:
+		if err := htmlReport.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render report: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unknown report format %d", opts.Format)
+	}
+	return buf.String(), nil
+}
+
+// inventoryOf lists the toolsets and workloads of an installation. Toolsets
+// which can't be listed are reported as none.
+func inventoryOf(install Installation) reportInventory {
+	inv := reportInventory{InstanceID: install.InstanceID}
+
+	toolsets, _ := Toolsets(install)
+	versions := make([]string, 0, len(toolsets))
+	for _, t := range toolsets {
+		versions = append(versions, t.Version)
+	}
+	inv.Toolsets = strings.Join(versions, ", ")
+
+	var workloads []string
+	for _, p := range install.Packages {
+		if strings.EqualFold(p.Type, "Workload") {
+			workloads = append(workloads, strings.TrimPrefix(p.ID, "Microsoft.VisualStudio.Workload."))
+		}
+	}
+	inv.Workloads = strings.Join(workloads, ", ")
+	return inv
+}
+
+// health describes the health of an installation in a few words.
+func health(i Installation) string {
+	var problems []string
+	if !i.IsComplete {
+		problems = append(problems, "incomplete")
+	}
+	if !i.IsLaunchable {
+		problems = append(problems, "not launchable")
+	}
+	if i.IsRebootRequired {
+		problems = append(problems, "reboot required")
+	}
+	if len(problems) == 0 {
+		return "OK"
+	}
+	return strings.Join(problems, ", ")
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+var reportFuncs = map[string]interface{}{
+	"health": health,
+	"md":     escapeMarkdown,
+}
+
+var markdownReport = template.Must(template.New("report").Funcs(reportFuncs).Parse(`# {{.Title}}
+
+Generated {{.Generated}}.
+{{with .Machine}}
+## Machine
+
+| OS | Architecture | Container | Virtual machine | Elevated |
+| --- | --- | --- | --- | --- |
+| {{.OSVersion}} | {{.Arch}} | {{.Container}} | {{.VirtualMachine}} | {{.Elevated}} |
+{{end}}
+## Instances
+{{if .Installs}}
+| Instance | Name | Version | Channel | Path | Health |
+| --- | --- | --- | --- | --- | --- |
+{{- range .Installs}}
+| {{md .InstanceID}} | {{md .DisplayName}} | {{md .InstallationVersion}} | {{md .ChannelID}} | {{md .InstallationPath}} | {{health .}} |
+{{- end}}
+{{else}}
+No instances found.
+{{end}}
+{{- if .Installs}}
+## Toolsets and workloads
+
+| Instance | MSVC toolsets | Workloads |
+| --- | --- | --- |
+{{- range .Inventory}}
+| {{md .InstanceID}} | {{md .Toolsets}} | {{md .Workloads}} |
+{{- end}}
+{{end}}
+## Windows SDKs
+{{if .SDKs}}
+{{range .SDKs}}* {{.}}
+{{end}}{{else}}
+No Windows SDKs found.
+{{end}}`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("report").Funcs(reportFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Generated {{.Generated}}.</p>
+{{with .Machine -}}
+<h2>Machine</h2>
+<table>
+<tr><th>OS</th><th>Architecture</th><th>Container</th><th>Virtual machine</th><th>Elevated</th></tr>
+<tr><td>{{.OSVersion}}</td><td>{{.Arch}}</td><td>{{.Container}}</td><td>{{.VirtualMachine}}</td><td>{{.Elevated}}</td></tr>
+</table>
+{{end -}}
+<h2>Instances</h2>
+{{if .Installs -}}
+<table>
+<tr><th>Instance</th><th>Name</th><th>Version</th><th>Channel</th><th>Path</th><th>Health</th></tr>
+{{range .Installs -}}
+<tr><td>{{.InstanceID}}</td><td>{{.DisplayName}}</td><td>{{.InstallationVersion}}</td><td>{{.ChannelID}}</td><td>{{.InstallationPath}}</td><td>{{health .}}</td></tr>
+{{end -}}
+</table>
+<h2>Toolsets and workloads</h2>
+<table>
+<tr><th>Instance</th><th>MSVC toolsets</th><th>Workloads</th></tr>
+{{range .Inventory -}}
+<tr><td>{{.InstanceID}}</td><td>{{.Toolsets}}</td><td>{{.Workloads}}</td></tr>
+{{end -}}
+</table>
+{{- else -}}
+<p>No instances found.</p>
+{{- end}}
+<h2>Windows SDKs</h2>
+{{if .SDKs -}}
+<ul>
+{{range .SDKs}}<li>{{.}}</li>
+{{end -}}
+</ul>
+{{- else -}}
+<p>No Windows SDKs found.</p>
+{{- end}}
+</body>
+</html>
+`))
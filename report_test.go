@@ -0,0 +1,40 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "cl.exe")
+
+	installs := []Installation{
+		{
+			InstanceID: "abc", DisplayName: "Visual Studio Community 2019", IsComplete: true, IsLaunchable: true,
+			InstallationPath: root,
+			Packages: []Package{
+				{ID: "Microsoft.VisualStudio.Workload.NativeDesktop", Type: "Workload"},
+				{ID: "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", Type: "Component"},
+				{ID: "Microsoft.VisualStudio.Workload.ManagedDesktop", Type: "Workload"},
+			},
+		},
+		{InstanceID: "def", DisplayName: "Visual Studio <Preview>", IsComplete: false, IsLaunchable: true, IsRebootRequired: true},
+	}
+
+	md, err := Report(installs, ReportOptions{})
+	require.NoError(t, err)
+	require.Contains(t, md, "# Visual Studio Report\n")
+	require.Contains(t, md, "| abc | Visual Studio Community 2019 |  |  | "+root+" | OK |\n")
+	require.Contains(t, md, "| incomplete, reboot required |\n")
+	require.Contains(t, md, "| abc | 14.38.33130, 14.29.30133 | NativeDesktop, ManagedDesktop |\n")
+	require.Contains(t, md, "| def |  |  |\n")
+
+	html, err := Report(installs, ReportOptions{Format: ReportHTML, Title: "Agent"})
+	require.NoError(t, err)
+	require.Contains(t, html, "<title>Agent</title>")
+	require.Contains(t, html, "<td>Visual Studio &lt;Preview&gt;</td>")
+	require.Contains(t, html, "<tr><td>abc</td><td>14.38.33130, 14.29.30133</td><td>NativeDesktop, ManagedDesktop</td></tr>")
+}
@@ -0,0 +1,44 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WindowsSDKComponent returns the ID of the component which installs a
+// Windows SDK version (e.g., "10.0.22621.0") with Visual Studio.
+func WindowsSDKComponent(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 3 || parts[0] != "10" {
+		return "", fmt.Errorf("unsupported Windows SDK version %s", version)
+	}
+	build, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("unsupported Windows SDK version %s", version)
+	}
+	if build >= 22000 {
+		return "Microsoft.VisualStudio.Component.Windows11SDK." + parts[2], nil
+	}
+	return "Microsoft.VisualStudio.Component.Windows10SDK." + parts[2], nil
+}
+
+// FindWithWindowsSDK finds installations which installed the Windows SDK
+// version (e.g., "10.0.22621.0"). Windows SDKs installed outside of Visual
+// Studio aren't tied to an installation and won't be matched; use
+// winsdk.Versions to find those. Options can be provided to further customize
+// the search.
+func FindWithWindowsSDK(ctx context.Context, version string, options ...Option) ([]Installation, error) {
+	return Default().FindWithWindowsSDK(ctx, version, options...)
+}
+
+// FindWithWindowsSDK finds installations which installed a Windows SDK using
+// the Finder.
+func (f *Finder) FindWithWindowsSDK(ctx context.Context, version string, options ...Option) ([]Installation, error) {
+	id, err := WindowsSDKComponent(version)
+	if err != nil {
+		return nil, err
+	}
+	return f.Find(ctx, append(options, withRequired(id))...)
+}
@@ -0,0 +1,24 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowsSDKComponent(t *testing.T) {
+	for version, expect := range map[string]string{
+		"10.0.17763.0": "Microsoft.VisualStudio.Component.Windows10SDK.17763",
+		"10.0.19041.0": "Microsoft.VisualStudio.Component.Windows10SDK.19041",
+		"10.0.22621.0": "Microsoft.VisualStudio.Component.Windows11SDK.22621",
+	} {
+		id, err := WindowsSDKComponent(version)
+		require.NoError(t, err)
+		require.Equal(t, expect, id)
+	}
+
+	_, err := WindowsSDKComponent("10.0")
+	require.Error(t, err)
+	_, err = WindowsSDKComponent("8.1")
+	require.Error(t, err)
+}
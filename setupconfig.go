@@ -0,0 +1,346 @@
+//go:build windows
+// +build windows
+
+package vswhere
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// The Setup Configuration API is the COM API vswhere itself uses to find
+// installations. It is queried directly when vswhere.exe isn't installed.
+var (
+	clsidSetupConfiguration = windows.GUID{Data1: 0x177F0C4A, Data2: 0x1CD3, Data3: 0x4DE7, Data4: [8]byte{0xA3, 0x2C, 0x71, 0xDB, 0xBB, 0x9F, 0xA3, 0x6D}}
+	iidSetupConfiguration2  = windows.GUID{Data1: 0x26AAB78C, Data2: 0x4A60, Data3: 0x49D6, Data4: [8]byte{0xAF, 0x3B, 0x3C, 0x35, 0xBC, 0x93, 0x36, 0x5D}}
+	iidSetupInstance2       = windows.GUID{Data1: 0x89143C9A, Data2: 0x05AF, Data3: 0x49B0, Data4: [8]byte{0xB7, 0x17, 0x72, 0xE2, 0x18, 0xA2, 0x18, 0x5C}}
+	iidSetupInstanceCatalog = windows.GUID{Data1: 0x9AD8E40F, Data2: 0x39A2, Data3: 0x40F1, Data4: [8]byte{0xBF, 0x64, 0x0A, 0x6C, 0x50, 0xDD, 0x9E, 0xEB}}
+
+	modole32    = windows.NewLazySystemDLL("ole32.dll")
+	modoleaut32 = windows.NewLazySystemDLL("oleaut32.dll")
+	modkernel32 = windows.NewLazySystemDLL("kernel32.dll")
+
+	procCoCreateInstance      = modole32.NewProc("CoCreateInstance")
+	procSysFreeString         = modoleaut32.NewProc("SysFreeString")
+	procSafeArrayGetLBound    = modoleaut32.NewProc("SafeArrayGetLBound")
+	procSafeArrayGetUBound    = modoleaut32.NewProc("SafeArrayGetUBound")
+	procSafeArrayAccessData   = modoleaut32.NewProc("SafeArrayAccessData")
+	procSafeArrayUnaccessData = modoleaut32.NewProc("SafeArrayUnaccessData")
+	procSafeArrayDestroy      = modoleaut32.NewProc("SafeArrayDestroy")
+	procGetUserDefaultLCID    = modkernel32.NewProc("GetUserDefaultLCID")
+)
+
+var errSetupInstanceMissing = fmt.Errorf("instance not found")
+
+// Vtable indices of the methods used from the Setup Configuration API.
+const (
+	methodQueryInterface = 0
+	methodRelease        = 2
+
+	// ISetupConfiguration(2)
+	methodGetInstanceForPath = 5
+	methodEnumAllInstances   = 6
+
+	// IEnumSetupInstances
+	methodNext = 3
+
+	// ISetupInstance(2)
+	methodGetInstanceID          = 3
+	methodGetInstallDate         = 4
+	methodGetInstallationName    = 5
+	methodGetInstallationPath    = 6
+	methodGetInstallationVersion = 7
+	methodGetDisplayName         = 8
+	methodGetDescription         = 9
+	methodGetState               = 11
+	methodGetPackages            = 12
+	methodGetProduct             = 13
+	methodGetProductPath         = 14
+	methodIsLaunchable           = 16
+	methodIsComplete             = 17
+	methodGetEnginePath          = 19
+
+	// ISetupPackageReference
+	methodGetID       = 3
+	methodGetVersion  = 4
+	methodGetChip     = 5
+	methodGetLanguage = 6
+	methodGetType     = 8
+
+	// ISetupInstanceCatalog
+	methodIsPrerelease = 4
+)
+
+const (
+	hresultSFalse      = 0x00000001
+	hresultNotFound    = 0x80070490
+	hresultClassNotReg = 0x80040154
+	hresultChangedMode = 0x80010106
+)
+
+// comObject is a COM interface pointer. Its first word points to the
+// interface's vtable.
+type comObject struct {
+	vtbl *[32]uintptr
+}
+
+// call invokes the vtable method with args, returning an error for failing
+// HRESULTs.
+func (o *comObject) call(method int, args ...uintptr) (uintptr, error) {
+	a := make([]uintptr, 9)
+	a[0] = uintptr(unsafe.Pointer(o))
+	copy(a[1:], args)
+	n := uintptr(len(args) + 1)
+
+	var hr uintptr
+	switch {
+	case n <= 3:
+		hr, _, _ = syscall.Syscall(o.vtbl[method], n, a[0], a[1], a[2])
+	case n <= 6:
+		hr, _, _ = syscall.Syscall6(o.vtbl[method], n, a[0], a[1], a[2], a[3], a[4], a[5])
+	default:
+		hr, _, _ = syscall.Syscall9(o.vtbl[method], n, a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8])
+	}
+	if int32(hr) < 0 {
+		return hr, syscall.Errno(hr)
+	}
+	return hr, nil
+}
+
+func (o *comObject) release() { _, _ = o.call(methodRelease) }
+
+func (o *comObject) queryInterface(iid *windows.GUID) (*comObject, error) {
+	var res *comObject
+	if _, err := o.call(methodQueryInterface, uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&res))); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getString calls a method returning a BSTR as its last argument.
+func (o *comObject) getString(method int, args ...uintptr) (string, error) {
+	var bstr *uint16
+	if _, err := o.call(method, append(args, uintptr(unsafe.Pointer(&bstr)))...); err != nil {
+		return "", err
+	}
+	defer procSysFreeString.Call(uintptr(unsafe.Pointer(bstr)))
+	return windows.UTF16PtrToString(bstr), nil
+}
+
+// getBool calls a method returning a VARIANT_BOOL.
+func (o *comObject) getBool(method int) (bool, error) {
+	var b int16
+	if _, err := o.call(method, uintptr(unsafe.Pointer(&b))); err != nil {
+		return false, err
+	}
+	return b != 0, nil
+}
+
+// setupInstances finds installations through the Setup Configuration API,
+// applying the same filters as vswhere. If so.path is set, only the
+// installation at that path is returned.
+func setupInstances(so *searchOptions) ([]Installation, error) {
+	if len(so.requires) > 0 {
+		return nil, fmt.Errorf("%w: WithRequires needs vswhere.exe", errBackendUnavailable)
+	}
+
+	// COM is initialized per thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	switch err := windows.CoInitializeEx(0, windows.COINIT_APARTMENTTHREADED); {
+	case err == nil || err == syscall.Errno(hresultSFalse):
+		defer windows.CoUninitialize()
+	case err == syscall.Errno(hresultChangedMode):
+		// Already initialized by someone else with a different model.
+	default:
+		return nil, fmt.Errorf("failed to initialize COM: %w", err)
+	}
+
+	var config *comObject
+	hr, _, _ := procCoCreateInstance.Call(
+		uintptr(unsafe.Pointer(&clsidSetupConfiguration)),
+		0,
+		windows.CLSCTX_INPROC_SERVER,
+		uintptr(unsafe.Pointer(&iidSetupConfiguration2)),
+		uintptr(unsafe.Pointer(&config)),
+	)
+	if hr == hresultClassNotReg {
+		// The Visual Studio Installer isn't installed.
+		return nil, nil
+	} else if int32(hr) < 0 {
+		return nil, fmt.Errorf("failed to create setup configuration: %w", syscall.Errno(hr))
+	}
+	defer config.release()
+
+	if so.path != "" {
+		install, err := setupInstanceForPath(config, so.path, so.includePackages())
+		if err == errSetupInstanceMissing {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return []Installation{install}, nil
+	}
+
+	var e *comObject
+	if _, err := config.call(methodEnumAllInstances, uintptr(unsafe.Pointer(&e))); err != nil {
+		return nil, fmt.Errorf("failed to enumerate instances: %w", err)
+	}
+	defer e.release()
+
+	var installs []Installation
+	for {
+		var (
+			inst    *comObject
+			fetched uint32
+		)
+		hr, err := e.call(methodNext, 1, uintptr(unsafe.Pointer(&inst)), uintptr(unsafe.Pointer(&fetched)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to enumerate instances: %w", err)
+		} else if hr == hresultSFalse || fetched == 0 {
+			break
+		}
+
+		install, err := readSetupInstance(inst, so.includePackages())
+		inst.release()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := so.matches(install)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			installs = append(installs, install)
+		}
+	}
+
+	return so.filterLatest(installs), nil
+}
+
+func setupInstanceForPath(config *comObject, path string, packages bool) (Installation, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return Installation{}, err
+	}
+
+	var inst *comObject
+	hr, err := config.call(methodGetInstanceForPath, uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&inst)))
+	if hr == hresultNotFound {
+		return Installation{}, errSetupInstanceMissing
+	} else if err != nil {
+		return Installation{}, fmt.Errorf("failed to get instance for %s: %w", path, err)
+	}
+	defer inst.release()
+	return readSetupInstance(inst, packages)
+}
+
+// readSetupInstance converts an ISetupInstance into an Installation. The
+// catalog and properties of the instance aren't read, and its packages are
+// only read if packages is true.
+func readSetupInstance(inst *comObject, packages bool) (Installation, error) {
+	inst2, err := inst.queryInterface(&iidSetupInstance2)
+	if err != nil {
+		return Installation{}, fmt.Errorf("failed to query ISetupInstance2: %w", err)
+	}
+	defer inst2.release()
+
+	var install Installation
+	lcid, _, _ := procGetUserDefaultLCID.Call()
+	for _, f := range []struct {
+		method int
+		dst    *string
+		args   []uintptr
+	}{
+		{methodGetInstanceID, &install.InstanceID, nil},
+		{methodGetInstallationName, &install.InstallationName, nil},
+		{methodGetInstallationPath, &install.InstallationPath, nil},
+		{methodGetInstallationVersion, &install.InstallationVersion, nil},
+		{methodGetDisplayName, &install.DisplayName, []uintptr{lcid}},
+		{methodGetDescription, &install.Description, []uintptr{lcid}},
+		{methodGetProductPath, &install.ProductPath, nil},
+		{methodGetEnginePath, &install.EnginePath, nil},
+	} {
+		// Properties may be missing for incomplete instances.
+		*f.dst, _ = inst2.getString(f.method, f.args...)
+	}
+
+	var ft windows.Filetime
+	if _, err := inst2.call(methodGetInstallDate, uintptr(unsafe.Pointer(&ft))); err == nil {
+		install.InstallDate = time.Unix(0, ft.Nanoseconds()).UTC()
+	}
+
+	var state uint32
+	if _, err := inst2.call(methodGetState, uintptr(unsafe.Pointer(&state))); err == nil {
+		install.State = uint64(state)
+	}
+	install.IsComplete, _ = inst2.getBool(methodIsComplete)
+	install.IsLaunchable, _ = inst2.getBool(methodIsLaunchable)
+
+	var product *comObject
+	if _, err := inst2.call(methodGetProduct, uintptr(unsafe.Pointer(&product))); err == nil && product != nil {
+		install.ProductID, _ = product.getString(methodGetID)
+		product.release()
+	}
+
+	if catalog, err := inst.queryInterface(&iidSetupInstanceCatalog); err == nil {
+		install.IsPrerelease, _ = catalog.getBool(methodIsPrerelease)
+		catalog.release()
+	}
+
+	if packages {
+		if install.Packages, err = readPackages(inst2); err != nil {
+			return Installation{}, err
+		}
+	}
+	return install, nil
+}
+
+// readPackages reads the packages of an ISetupInstance2, which are returned
+// as a SAFEARRAY of ISetupPackageReference.
+func readPackages(inst2 *comObject) ([]Package, error) {
+	var psa uintptr
+	if _, err := inst2.call(methodGetPackages, uintptr(unsafe.Pointer(&psa))); err != nil {
+		return nil, fmt.Errorf("failed to get packages: %w", err)
+	}
+	// Destroying the array releases the references it holds.
+	defer procSafeArrayDestroy.Call(psa)
+
+	var lower, upper int32
+	procSafeArrayGetLBound.Call(psa, 1, uintptr(unsafe.Pointer(&lower)))
+	procSafeArrayGetUBound.Call(psa, 1, uintptr(unsafe.Pointer(&upper)))
+	n := int(upper - lower + 1)
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var data unsafe.Pointer
+	if hr, _, _ := procSafeArrayAccessData.Call(psa, uintptr(unsafe.Pointer(&data))); int32(hr) < 0 {
+		return nil, fmt.Errorf("failed to get packages: %w", syscall.Errno(hr))
+	}
+	defer procSafeArrayUnaccessData.Call(psa)
+
+	refs := (*[1 << 20]*comObject)(data)[:n:n]
+	res := make([]Package, 0, n)
+	for _, ref := range refs {
+		var p Package
+		for _, f := range []struct {
+			method int
+			dst    *string
+		}{
+			{methodGetID, &p.ID},
+			{methodGetVersion, &p.Version},
+			{methodGetChip, &p.Chip},
+			{methodGetLanguage, &p.Language},
+			{methodGetType, &p.Type},
+		} {
+			*f.dst, _ = ref.getString(f.method)
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
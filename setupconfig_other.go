@@ -0,0 +1,9 @@
+//+build !windows
+
+package vswhere
+
+// setupInstances finds installations through the Setup Configuration API,
+// which only exists on Windows.
+func setupInstances(so *searchOptions) ([]Installation, error) {
+	return nil, ErrUnsupportedPlatform
+}
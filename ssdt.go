@@ -0,0 +1,88 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// SSDTComponent is the component ID of SQL Server Data Tools.
+const SSDTComponent = "Microsoft.VisualStudio.Component.SQL.SSDT"
+
+// SSDT is an installation with SQL Server Data Tools.
+type SSDT struct {
+	Installation Installation
+
+	// DacPath is the directory holding the bundled DacFx libraries.
+	DacPath string
+
+	// SQLPackage is the path to the bundled SqlPackage.exe.
+	SQLPackage string
+}
+
+// FindSSDT finds installations with SQL Server Data Tools and locates their
+// bundled DacFx. Installations whose DacFx can't be found are skipped. Options
+// can be provided to further customize the search.
+func FindSSDT(ctx context.Context, options ...Option) ([]SSDT, error) {
+	return Default().FindSSDT(ctx, options...)
+}
+
+// FindSSDT finds installations with SQL Server Data Tools using the Finder.
+func (f *Finder) FindSSDT(ctx context.Context, options ...Option) ([]SSDT, error) {
+	options = append(options, withRequired(SSDTComponent))
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SSDT, 0, len(installs))
+	for _, install := range installs {
+		dacPath, err := DacFxPath(install)
+		if err != nil {
+			f.logf("skipping %s: %v", install.InstanceID, err)
+			continue
+		}
+		res = append(res, SSDT{
+			Installation: install,
+			DacPath:      dacPath,
+			SQLPackage:   filepath.Join(dacPath, "SqlPackage.exe"),
+		})
+	}
+	return res, nil
+}
+
+// DacFxPath returns the directory of the DacFx bundled with SQL Server Data
+// Tools in an installation. Visual Studio 2022 places DacFx directly in the
+// DAC directory, while older versions use a versioned subdirectory (e.g.,
+// "DAC\150"); the newest one is returned.
+func DacFxPath(install Installation) (string, error) {
+	dac := filepath.Join(install.InstallationPath, "Common7", "IDE", "Extensions", "Microsoft", "SQLDB", "DAC")
+	if _, err := os.Stat(filepath.Join(dac, "SqlPackage.exe")); err == nil {
+		return dac, nil
+	}
+
+	entries, err := ioutil.ReadDir(dac)
+	if err != nil {
+		return "", fmt.Errorf("DacFx not found in %s: %w", install.InstallationPath, err)
+	}
+	var best string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dac, e.Name(), "SqlPackage.exe")); err != nil {
+			continue
+		}
+		if best == "" || version.Compare(e.Name(), best) > 0 {
+			best = e.Name()
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("DacFx not found in %s", install.InstallationPath)
+	}
+	return filepath.Join(dac, best), nil
+}
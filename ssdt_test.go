@@ -0,0 +1,83 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDacFxPath(t *testing.T) {
+	dac := []string{"Common7", "IDE", "Extensions", "Microsoft", "SQLDB", "DAC"}
+
+	vs2022 := t.TempDir()
+	mkfile(t, append(append([]string{vs2022}, dac...), "SqlPackage.exe")...)
+	path, err := DacFxPath(Installation{InstallationPath: vs2022})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(append([]string{vs2022}, dac...)...), path)
+
+	vs2019 := t.TempDir()
+	mkfile(t, append(append([]string{vs2019}, dac...), "130", "SqlPackage.exe")...)
+	mkfile(t, append(append([]string{vs2019}, dac...), "150", "SqlPackage.exe")...)
+	path, err = DacFxPath(Installation{InstallationPath: vs2019})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(append(append([]string{vs2019}, dac...), "150")...), path)
+
+	_, err = DacFxPath(Installation{InstallationPath: t.TempDir()})
+	require.Error(t, err)
+}
+
+func TestFinderFindSSDT(t *testing.T) {
+	dac := []string{"Common7", "IDE", "Extensions", "Microsoft", "SQLDB", "DAC"}
+	withDac := t.TempDir()
+	mkfile(t, append(append([]string{withDac}, dac...), "SqlPackage.exe")...)
+	withoutDac := t.TempDir()
+
+	// "c" only matched because of WithRequiresAny and must be filtered out.
+	r := &fakeRunner{stdout: `[
+  {"instanceId": "a", "installationPath": "` + filepath.ToSlash(withDac) + `", "packages": [{"id": "` + SSDTComponent + `"}]},
+  {"instanceId": "b", "installationPath": "` + filepath.ToSlash(withoutDac) + `", "packages": [{"id": "` + SSDTComponent + `"}]},
+  {"instanceId": "c", "installationPath": "` + filepath.ToSlash(withDac) + `", "packages": [{"id": "Other"}]}
+]`}
+	requires := []string{"Other"}
+	ssdts, err := NewFinder(WithFinderRunner(r)).FindSSDT(context.Background(), WithRequires(requires), WithRequiresAny(true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Other"}, requires)
+	require.Equal(t, []string{"-requires", "Other", SSDTComponent, "-requiresAny", "-include", "packages", "-format", "json"}, r.args)
+
+	require.Len(t, ssdts, 1)
+	require.Equal(t, "a", ssdts[0].Installation.InstanceID)
+	require.Empty(t, ssdts[0].Installation.Packages)
+	require.Equal(t, filepath.Join(ssdts[0].DacPath, "SqlPackage.exe"), ssdts[0].SQLPackage)
+}
+
+func TestFinderFindSSDTOffline(t *testing.T) {
+	dac := []string{"Common7", "IDE", "Extensions", "Microsoft", "SQLDB", "DAC"}
+	withSSDT := t.TempDir()
+	mkfile(t, append(append([]string{withSSDT}, dac...), "SqlPackage.exe")...)
+	withoutSSDT := t.TempDir()
+	mkfile(t, append(append([]string{withoutSSDT}, dac...), "SqlPackage.exe")...)
+
+	// The injected SSDT requirement is checked against the packages in the
+	// state files, since the offline backend can't filter by component.
+	dir := t.TempDir()
+	writeState(t, dir, `{
+  "installationPath": "`+filepath.ToSlash(withSSDT)+`",
+  "installationVersion": "17.8.34330.188",
+  "product": {"id": "Microsoft.VisualStudio.Product.Enterprise"},
+  "packages": [{"id": "`+SSDTComponent+`"}]
+}`, "a")
+	writeState(t, dir, `{
+  "installationPath": "`+filepath.ToSlash(withoutSSDT)+`",
+  "installationVersion": "17.8.34330.188",
+  "product": {"id": "Microsoft.VisualStudio.Product.Enterprise"},
+  "packages": [{"id": "Other"}]
+}`, "b")
+
+	ssdts, err := NewFinder(WithFinderOffline(dir)).FindSSDT(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ssdts, 1)
+	require.Equal(t, "a", ssdts[0].Installation.InstanceID)
+	require.Empty(t, ssdts[0].Installation.Packages)
+}
@@ -0,0 +1,103 @@
+package vswhere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// InstallerStatus describes the state of the Visual Studio Installer on a
+// machine.
+type InstallerStatus int
+
+// Installer statuses.
+const (
+	// NoInstaller means the Visual Studio Installer isn't present. The
+	// bootstrapper must be run to install anything.
+	NoInstaller InstallerStatus = iota
+
+	// InstallerOnly means the Visual Studio Installer is present but has no
+	// installations, such as after every installation was removed. The
+	// bootstrapper must be run to install a product; there is nothing to
+	// modify.
+	InstallerOnly
+
+	// Installed means at least one complete installation exists and can be
+	// modified.
+	Installed
+
+	// PartiallyInstalled means the Visual Studio Installer is present and
+	// has started an installation, but none are complete, such as after a
+	// bootstrapper was interrupted. The installation must be resumed or
+	// repaired before it can be used.
+	PartiallyInstalled
+)
+
+// String returns the name of the status.
+func (s InstallerStatus) String() string {
+	switch s {
+	case NoInstaller:
+		return "no installer"
+	case InstallerOnly:
+		return "installer only"
+	case Installed:
+		return "installed"
+	case PartiallyInstalled:
+		return "partially installed"
+	default:
+		return "unknown"
+	}
+}
+
+// Status reports whether the Visual Studio Installer and any installations
+// are present, so provisioning tools know whether to run a bootstrapper,
+// resume an interrupted installation, or modify an existing installation.
+// Installations of every product are counted, including incomplete and
+// prerelease ones, as are instances the installer has only begun to record.
+// Options can be provided to customize how vswhere is run.
+func Status(ctx context.Context, options ...Option) (InstallerStatus, error) {
+	return Default().Status(ctx, options...)
+}
+
+// Status reports whether the installer and any installations are present
+// using the Finder.
+func (f *Finder) Status(ctx context.Context, options ...Option) (InstallerStatus, error) {
+	installs, err := f.Find(ctx, append(options, WithAll(true), WithPrerelease(true), WithProducts([]string{"*"}))...)
+	if err != nil {
+		return NoInstaller, err
+	}
+	stateDir := f.stateDir
+	if stateDir == "" {
+		stateDir = defaultStateDir()
+	}
+	return installerStatus(installerDir(), stateDir, installs), nil
+}
+
+func installerDir() string {
+	return filepath.Join(os.Getenv("ProgramFiles(x86)"), "Microsoft Visual Studio", "Installer")
+}
+
+func installerStatus(dir, stateDir string, installs []Installation) InstallerStatus {
+	for _, install := range installs {
+		if install.IsComplete {
+			return Installed
+		}
+	}
+	if len(installs) > 0 {
+		return PartiallyInstalled
+	}
+
+	// The installer records an instance before vswhere reports it.
+	if entries, err := ioutil.ReadDir(stateDir); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				return PartiallyInstalled
+			}
+		}
+	}
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		return InstallerOnly
+	}
+	return NoInstaller
+}
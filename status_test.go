@@ -0,0 +1,26 @@
+package vswhere
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallerStatus(t *testing.T) {
+	dir, stateDir := t.TempDir(), t.TempDir()
+	complete := Installation{InstanceID: "a", IsComplete: true}
+	incomplete := Installation{InstanceID: "b"}
+
+	require.Equal(t, Installed, installerStatus(dir, stateDir, []Installation{incomplete, complete}))
+	require.Equal(t, PartiallyInstalled, installerStatus(dir, stateDir, []Installation{incomplete}))
+	require.Equal(t, InstallerOnly, installerStatus(dir, stateDir, nil))
+	require.Equal(t, NoInstaller, installerStatus(filepath.Join(dir, "missing"), stateDir, nil))
+
+	require.NoError(t, os.Mkdir(filepath.Join(stateDir, "1a2b3c4d"), 0755))
+	require.Equal(t, PartiallyInstalled, installerStatus(dir, stateDir, nil))
+
+	require.Equal(t, "installer only", InstallerOnly.String())
+	require.Equal(t, "partially installed", PartiallyInstalled.String())
+}
@@ -0,0 +1,282 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// Toolset is an MSVC toolset installed within an installation.
+type Toolset struct {
+	// Version of the toolset (e.g., "14.38.33130").
+	Version string
+
+	// Path to the toolset (e.g., "VC\Tools\MSVC\14.38.33130").
+	Path string
+
+	// InstallationPath is the path of the installation providing the
+	// toolset.
+	InstallationPath string
+}
+
+// VC140Component is the component ID of the Visual Studio 2015 (v140) C++
+// toolset in Visual Studio 2017 and later.
+const VC140Component = "Microsoft.VisualStudio.Component.VC.140"
+
+// Toolsets returns the MSVC toolsets installed within an installation, newest
+// first. Returns no toolsets if the C++ tools aren't installed.
+//
+// The Visual Studio 2015 toolset is reported with version "14.0". Later
+// versions of Visual Studio use it from the Visual Studio 2015 VC directory,
+// where the VC140Component installs it. They only report it if they have the
+// component, so the installation must be found with WithIncludePackages.
+func Toolsets(install Installation) ([]Toolset, error) {
+	var toolsets []Toolset
+	if t, ok := v140Toolset(install); ok {
+		toolsets = append(toolsets, t)
+	}
+
+	dir := filepath.Join(install.InstallationPath, "VC", "Tools", "MSVC")
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return toolsets, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list MSVC toolsets: %w", err)
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), "14.") {
+			continue
+		}
+		toolsets = append(toolsets, Toolset{
+			Version:          e.Name(),
+			Path:             filepath.Join(dir, e.Name()),
+			InstallationPath: install.InstallationPath,
+		})
+	}
+	sort.Slice(toolsets, func(i, j int) bool {
+		return version.Compare(toolsets[i].Version, toolsets[j].Version) > 0
+	})
+	return toolsets, nil
+}
+
+// FindWithToolset finds installations providing an MSVC toolset of at least
+// minVersion (e.g., "14.30"). An installation's version doesn't determine
+// which toolsets it has, so the toolsets installed within each installation
+// are inspected. Options can be provided to customize the search.
+func FindWithToolset(ctx context.Context, minVersion string, options ...Option) ([]Installation, error) {
+	return Default().FindWithToolset(ctx, minVersion, options...)
+}
+
+// FindWithToolset finds installations providing an MSVC toolset using the
+// Finder.
+func (f *Finder) FindWithToolset(ctx context.Context, minVersion string, options ...Option) ([]Installation, error) {
+	installs, err := f.Find(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return filterByToolset(installs, minVersion)
+}
+
+func filterByToolset(installs []Installation, minVersion string) ([]Installation, error) {
+	var res []Installation
+	for _, install := range installs {
+		toolsets, err := Toolsets(install)
+		if err != nil {
+			return nil, err
+		}
+		// Toolsets are sorted newest first.
+		if len(toolsets) > 0 && version.Compare(toolsets[0].Version, minVersion) >= 0 {
+			res = append(res, install)
+		}
+	}
+	return res, nil
+}
+
+// v140Toolset returns the Visual Studio 2015 toolset usable by an
+// installation: its own VC directory for Visual Studio 2015, or the Visual
+// Studio 2015 VC directory for later versions which installed VC140Component.
+func v140Toolset(install Installation) (Toolset, bool) {
+	dir := filepath.Join(install.InstallationPath, "VC")
+	if strings.HasPrefix(install.InstallationVersion, "14.") {
+		if _, err := os.Stat(filepath.Join(dir, "bin", "cl.exe")); err != nil {
+			return Toolset{}, false
+		}
+	} else {
+		if len(installedPackages(install, []string{VC140Component})) == 0 {
+			return Toolset{}, false
+		}
+		dir = filepath.Join(vs140Dir(), "VC")
+	}
+	return Toolset{Version: "14.0", Path: dir, InstallationPath: install.InstallationPath}, true
+}
+
+// vs140Dir returns the installation directory of Visual Studio 2015.
+var vs140Dir = func() string {
+	return filepath.Join(os.Getenv("ProgramFiles(x86)"), "Microsoft Visual Studio 14.0")
+}
+
+// BinPath returns the directory of the compiler and linker for a host and
+// target architecture.
+func (t Toolset) BinPath(host, target Arch) string {
+	if t.Version == "14.0" {
+		// Visual Studio 2015 names directories after the host and target
+		// (e.g., "bin\\x86_amd64"), except for native tools.
+		name := v140Arch(host) + "_" + v140Arch(target)
+		switch {
+		case host == X86 && target == X86:
+			name = ""
+		case host == target:
+			name = v140Arch(host)
+		}
+		return filepath.Join(t.Path, "bin", name)
+	}
+	return filepath.Join(t.Path, "bin", "Host"+string(host), string(target))
+}
+
+func v140Arch(arch Arch) string {
+	if arch == X64 {
+		return "amd64"
+	}
+	return string(arch)
+}
+
+// PlatformToolset returns the MSBuild platform toolset of the toolset (e.g.,
+// "v143" for 14.38). Returns an empty string for unknown versions.
+func (t Toolset) PlatformToolset() string {
+	parts := strings.Split(t.Version, ".")
+	if len(parts) < 2 || parts[0] != "14" {
+		return ""
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	for _, pt := range platformToolsets {
+		if minor >= pt.minMinor && minor < pt.maxMinor {
+			return pt.name
+		}
+	}
+	return ""
+}
+
+// redistPath returns the redistributable directory matching the toolset's
+// major and minor version.
+func (t Toolset) redistPath() (string, error) {
+	dir := filepath.Join(t.InstallationPath, "VC", "Redist", "MSVC")
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list MSVC redistributables: %w", err)
+	}
+
+	prefix := majorMinor(t.Version) + "."
+	var best string
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if best == "" || version.Compare(e.Name(), best) > 0 {
+			best = e.Name()
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no redistributables for toolset %s", t.Version)
+	}
+	return filepath.Join(dir, best), nil
+}
+
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// RuntimeDLLs returns the paths of the runtime DLLs (vcruntime, msvcp,
+// concrt, etc.) that an application built with the toolset for arch must
+// ship. If debug is true, the debug runtime DLLs are returned instead; these
+// are not redistributable and are only intended for testing.
+func RuntimeDLLs(install Installation, toolset Toolset, arch Arch, debug bool) ([]string, error) {
+	if toolset.InstallationPath == "" {
+		toolset.InstallationPath = install.InstallationPath
+	}
+	dir, err := toolset.RuntimeDLLPath(arch, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	dlls, err := filepath.Glob(filepath.Join(dir, "*.dll"))
+	if err != nil {
+		return nil, err
+	} else if len(dlls) == 0 {
+		return nil, fmt.Errorf("no runtime DLLs found in %s", dir)
+	}
+	return dlls, nil
+}
+
+// RuntimeDLLPath returns the directory holding the runtime DLLs for arch. If
+// debug is true, the directory of the debug runtime DLLs is returned instead.
+func (t Toolset) RuntimeDLLPath(arch Arch, debug bool) (string, error) {
+	redist, err := t.redistPath()
+	if err != nil {
+		return "", err
+	}
+
+	crtName := "Microsoft.VC" + strings.TrimPrefix(t.PlatformToolset(), "v") + ".CRT"
+	if debug {
+		crtName = strings.TrimSuffix(crtName, "CRT") + "DebugCRT"
+		return filepath.Join(redist, "debug_nonredist", string(arch), crtName), nil
+	}
+	return filepath.Join(redist, string(arch), crtName), nil
+}
+
+// LibPath returns the directory of the toolset's libraries for a target
+// architecture. Both the release and debug CRT import libraries live here.
+func (t Toolset) LibPath(arch Arch) string {
+	return filepath.Join(t.Path, "lib", string(arch))
+}
+
+// CRTLibs returns the paths of the import libraries linked when building
+// with the dynamic CRT (/MD) for arch. If debug is true, the libraries for the
+// debug dynamic CRT (/MDd) are returned instead. The Universal CRT libraries
+// are part of the Windows SDK and are not included.
+func (t Toolset) CRTLibs(arch Arch, debug bool) []string {
+	suffix := ".lib"
+	if debug {
+		suffix = "d.lib"
+	}
+
+	dir := t.LibPath(arch)
+	return []string{
+		filepath.Join(dir, "msvcrt"+suffix),
+		filepath.Join(dir, "vcruntime"+suffix),
+		filepath.Join(dir, "msvcprt"+suffix),
+	}
+}
+
+// RedistInstaller returns the path of the Visual C++ Redistributable
+// installer (vc_redist.<arch>.exe) matching the toolset.
+func RedistInstaller(install Installation, toolset Toolset, arch Arch) (string, error) {
+	if toolset.InstallationPath == "" {
+		toolset.InstallationPath = install.InstallationPath
+	}
+	redist, err := toolset.redistPath()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(redist, "vc_redist."+string(arch)+".exe")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("redistributable installer not found: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,107 @@
+package vswhere
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolsets(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "cl.exe")
+
+	toolsets, err := Toolsets(Installation{InstallationPath: root})
+	require.NoError(t, err)
+	require.Len(t, toolsets, 2)
+	require.Equal(t, "14.38.33130", toolsets[0].Version)
+	require.Equal(t, "v143", toolsets[0].PlatformToolset())
+	require.Equal(t, "14.29.30133", toolsets[1].Version)
+	require.Equal(t, "v142", toolsets[1].PlatformToolset())
+	require.Equal(t, filepath.Join(root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64"), toolsets[0].BinPath(X64, X64))
+
+	toolsets, err = Toolsets(Installation{InstallationPath: filepath.Join(root, "missing")})
+	require.NoError(t, err)
+	require.Empty(t, toolsets)
+}
+
+func TestFilterByToolset(t *testing.T) {
+	vs2019, vs2022, empty := t.TempDir(), t.TempDir(), t.TempDir()
+	mkfile(t, vs2019, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, vs2022, "VC", "Tools", "MSVC", "14.29.30133", "bin", "Hostx64", "x64", "cl.exe")
+	mkfile(t, vs2022, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "cl.exe")
+
+	installs := []Installation{
+		{InstanceID: "vs2019", InstallationPath: vs2019},
+		{InstanceID: "vs2022", InstallationPath: vs2022},
+		{InstanceID: "empty", InstallationPath: empty},
+	}
+
+	res, err := filterByToolset(installs, "14.30")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "vs2022", res[0].InstanceID)
+
+	res, err = filterByToolset(installs, "14.29.30133")
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+}
+
+func TestRuntimeDLLs(t *testing.T) {
+	root := t.TempDir()
+	redist := filepath.Join(root, "VC", "Redist", "MSVC")
+	mkfile(t, redist, "14.38.33130", "x64", "Microsoft.VC143.CRT", "old.dll")
+	mkfile(t, redist, "14.38.33135", "x64", "Microsoft.VC143.CRT", "vcruntime140.dll")
+	mkfile(t, redist, "14.38.33135", "x64", "Microsoft.VC143.CRT", "msvcp140.dll")
+	mkfile(t, redist, "14.38.33135", "debug_nonredist", "x64", "Microsoft.VC143.DebugCRT", "vcruntime140d.dll")
+	mkfile(t, redist, "14.38.33135", "vc_redist.x64.exe")
+
+	install := Installation{InstallationPath: root}
+	toolset := Toolset{Version: "14.38.33130"}
+
+	dlls, err := RuntimeDLLs(install, toolset, X64, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(redist, "14.38.33135", "x64", "Microsoft.VC143.CRT", "msvcp140.dll"),
+		filepath.Join(redist, "14.38.33135", "x64", "Microsoft.VC143.CRT", "vcruntime140.dll"),
+	}, dlls)
+
+	dlls, err = RuntimeDLLs(install, toolset, X64, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(redist, "14.38.33135", "debug_nonredist", "x64", "Microsoft.VC143.DebugCRT", "vcruntime140d.dll"),
+	}, dlls)
+
+	_, err = RuntimeDLLs(install, toolset, ARM64, false)
+	require.Error(t, err)
+
+	path, err := RedistInstaller(install, toolset, X64)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(redist, "14.38.33135", "vc_redist.x64.exe"), path)
+}
+
+func TestCRTLibs(t *testing.T) {
+	toolset := Toolset{Version: "14.38.33130", Path: `C:\MSVC\14.38.33130`}
+
+	require.Equal(t, []string{
+		filepath.Join(toolset.Path, "lib", "x64", "msvcrt.lib"),
+		filepath.Join(toolset.Path, "lib", "x64", "vcruntime.lib"),
+		filepath.Join(toolset.Path, "lib", "x64", "msvcprt.lib"),
+	}, toolset.CRTLibs(X64, false))
+
+	require.Equal(t, []string{
+		filepath.Join(toolset.Path, "lib", "x86", "msvcrtd.lib"),
+		filepath.Join(toolset.Path, "lib", "x86", "vcruntimed.lib"),
+		filepath.Join(toolset.Path, "lib", "x86", "msvcprtd.lib"),
+	}, toolset.CRTLibs(X86, true))
+}
+
+func mkfile(t *testing.T, elem ...string) {
+	t.Helper()
+	path := filepath.Join(elem...)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+}
@@ -0,0 +1,7 @@
+//+build !windows
+
+package vswhere
+
+func uninstallEntries() ([]uninstallEntry, error) {
+	return nil, ErrUnsupportedPlatform
+}
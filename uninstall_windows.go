@@ -0,0 +1,45 @@
+//+build windows
+
+package vswhere
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// uninstallKey lists the programs which can be uninstalled from Windows.
+const uninstallKey = `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`
+
+// uninstallEntries returns the programs registered in uninstallKey of both
+// the 64-bit and 32-bit registry views.
+func uninstallEntries() ([]uninstallEntry, error) {
+	var entries []uninstallEntry
+	for _, view := range []uint32{registry.WOW64_64KEY, registry.WOW64_32KEY} {
+		k, err := registry.OpenKey(registry.LOCAL_MACHINE, uninstallKey, registry.READ|view)
+		if err == registry.ErrNotExist {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to open Uninstall registry key: %w", err)
+		}
+		names, err := k.ReadSubKeyNames(-1)
+		k.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list installed programs: %w", err)
+		}
+
+		for _, name := range names {
+			sk, err := registry.OpenKey(registry.LOCAL_MACHINE, uninstallKey+`\`+name, registry.QUERY_VALUE|view)
+			if err != nil {
+				continue
+			}
+			var e uninstallEntry
+			e.DisplayName, _, _ = sk.GetStringValue("DisplayName")
+			e.DisplayVersion, _, _ = sk.GetStringValue("DisplayVersion")
+			e.InstallLocation, _, _ = sk.GetStringValue("InstallLocation")
+			sk.Close()
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,88 @@
+package vswhere
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validate checks searchOptions for mistakes which vswhere would otherwise
+// reject with a less helpful message, returning every problem found.
+// Selection options aren't checked when searching by path, since they're
+// ignored.
+func (so *searchOptions) validate() error {
+	var errs []error
+	if so.path == "" {
+		if so.legacy && (len(so.products) > 0 || len(so.requires) > 0 || len(so.required) > 0) {
+			errs = append(errs, fmt.Errorf("%w: WithLegacy cannot be used with WithProducts or WithRequires", ErrInvalidArgument))
+		}
+		if so.requiresAny && len(so.requires) == 0 && len(so.required) == 0 {
+			errs = append(errs, fmt.Errorf("%w: WithRequiresAny requires WithRequires", ErrInvalidArgument))
+		}
+		if containsEmpty(so.products) {
+			errs = append(errs, fmt.Errorf("%w: empty product ID", ErrInvalidArgument))
+		}
+		if containsEmpty(so.requires) {
+			errs = append(errs, fmt.Errorf("%w: empty component ID", ErrInvalidArgument))
+		}
+		if so.version != "" {
+			if _, err := ParseVersionRange(so.version); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, arg := range so.extraArgs {
+		switch strings.ToLower(strings.TrimLeft(arg, "-/")) {
+		case "format", "property", "find":
+			errs = append(errs, fmt.Errorf("%w: WithExtraArgs cannot change the output format with %s", ErrInvalidArgument, arg))
+		}
+	}
+	return joinErrors(errs)
+}
+
+func containsEmpty(list []string) bool {
+	for _, s := range list {
+		if strings.TrimSpace(s) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// joinedError holds several errors, like errors.Join in newer versions of Go.
+type joinedError struct {
+	errs []error
+}
+
+// joinErrors returns nil if errs is empty, the only error if there is one,
+// and a joinedError otherwise.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &joinedError{errs: errs}
+}
+
+func (e *joinedError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether any of the joined errors match target.
+func (e *joinedError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the joined errors.
+func (e *joinedError) Unwrap() []error { return e.errs }
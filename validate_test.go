@@ -0,0 +1,45 @@
+package vswhere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildArgs_Validation(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithVersion("[16.0,17.0)")},
+		{WithVersion("17")},
+		{WithVersion("[17.8,17.8]")},
+		{WithVersion("(,18)")},
+		{WithRequires([]string{"Microsoft.VisualStudio.Component.VC.Tools.x86.x64"}), WithRequiresAny(true)},
+	} {
+		_, err := BuildArgs(opts...)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		opts   []Option
+		expect error
+	}{
+		{[]Option{WithVersion("[16.0")}, ErrInvalidVersionRange},
+		{[]Option{WithVersion("[16.0,17.0,18.0]")}, ErrInvalidVersionRange},
+		{[]Option{WithVersion("[abc,)")}, ErrInvalidVersionRange},
+		{[]Option{WithVersion("[18,17)")}, ErrInvalidVersionRange},
+		{[]Option{WithVersion("[17,17)")}, ErrInvalidVersionRange},
+		{[]Option{WithRequiresAny(true)}, ErrInvalidArgument},
+		{[]Option{WithProducts([]string{""})}, ErrInvalidArgument},
+		{[]Option{WithRequires([]string{" "})}, ErrInvalidArgument},
+		{[]Option{WithLegacy(true), WithProducts([]string{"*"})}, ErrInvalidArgument},
+	} {
+		_, err := BuildArgs(tc.opts...)
+		require.True(t, errors.Is(err, tc.expect), "unexpected error %v", err)
+	}
+
+	// Every problem is reported.
+	_, err := BuildArgs(WithVersion("[18,17)"), WithRequiresAny(true))
+	require.True(t, errors.Is(err, ErrInvalidVersionRange))
+	require.True(t, errors.Is(err, ErrInvalidArgument))
+	require.Contains(t, err.Error(), "WithRequiresAny")
+}
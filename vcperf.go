@@ -0,0 +1,38 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// VCPerf is the C++ Build Insights collector, vcperf.exe, shipped with a
+// toolset.
+type VCPerf struct {
+	// Path to vcperf.exe.
+	Path string
+}
+
+// VCPerf locates vcperf.exe in the toolset for a host and target
+// architecture. vcperf ships with toolsets since Visual Studio 2019 16.4.
+func (t Toolset) VCPerf(host, target Arch) (VCPerf, error) {
+	path := filepath.Join(t.BinPath(host, target), "vcperf.exe")
+	if _, err := os.Stat(path); err != nil {
+		return VCPerf{}, fmt.Errorf("vcperf not available for toolset %s: %w", t.Version, err)
+	}
+	return VCPerf{Path: path}, nil
+}
+
+// StartCommand returns a command that starts a tracing session named
+// session. vcperf requires administrator privileges.
+func (v VCPerf) StartCommand(ctx context.Context, session string) *exec.Cmd {
+	return exec.CommandContext(ctx, v.Path, "/start", session)
+}
+
+// StopCommand returns a command that stops the tracing session named session
+// and writes the trace to output, an .etl file.
+func (v VCPerf) StopCommand(ctx context.Context, session, output string) *exec.Cmd {
+	return exec.CommandContext(ctx, v.Path, "/stop", session, output)
+}
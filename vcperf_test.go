@@ -0,0 +1,28 @@
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVCPerf(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "VC", "Tools", "MSVC", "14.38.33130", "bin", "Hostx64", "x64", "vcperf.exe")
+
+	toolsets, err := Toolsets(Installation{InstallationPath: root})
+	require.NoError(t, err)
+	require.Len(t, toolsets, 1)
+
+	vcperf, err := toolsets[0].VCPerf(X64, X64)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(toolsets[0].BinPath(X64, X64), "vcperf.exe"), vcperf.Path)
+
+	cmd := vcperf.StopCommand(context.Background(), "build", "trace.etl")
+	require.Equal(t, []string{vcperf.Path, "/stop", "build", "trace.etl"}, cmd.Args)
+
+	_, err = toolsets[0].VCPerf(X86, X86)
+	require.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package vswhere
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/rfratto/vswhere/internal/filever"
+)
+
+// versionPattern matches the version in the banner printed by vswhere, such
+// as "Visual Studio Locator version 3.1.7+f39851e70f".
+var versionPattern = regexp.MustCompile(`version (\d+(?:\.\d+)+)`)
+
+// Version returns the version of the installed vswhere (e.g., "3.1.7"), which
+// determines the flags it supports.
+func Version(ctx context.Context) (string, error) {
+	return Default().Version(ctx)
+}
+
+// Version returns the version of the vswhere used by the Finder. The version
+// resource of the executable is read when possible; otherwise vswhere is run
+// to print its banner, such as when a Runner is used.
+func (f *Finder) Version(ctx context.Context) (string, error) {
+	var so searchOptions
+	runner, err := f.vswhereRunner(&so)
+	if err != nil {
+		return "", err
+	}
+	if r, ok := runner.(execRunner); ok {
+		if v, err := filever.Get(r.path); err == nil {
+			return v, nil
+		}
+	}
+
+	out, err := f.RunRaw(ctx, "-?")
+	if err != nil {
+		return "", err
+	}
+	m := versionPattern.FindSubmatch(out)
+	if m == nil {
+		return "", fmt.Errorf("no version in vswhere output")
+	}
+	return string(m[1]), nil
+}
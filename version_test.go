@@ -0,0 +1,20 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinderVersion(t *testing.T) {
+	r := &fakeRunner{stdout: "Visual Studio Locator version 3.1.7+f39851e70f [query version 3.7.2182.31708]\r\nCopyright (C) Microsoft Corporation. All rights reserved.\r\n"}
+	v, err := NewFinder(WithFinderRunner(r)).Version(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "3.1.7", v)
+	require.Equal(t, []string{"-?"}, r.args)
+
+	r.stdout = "Usage: vswhere.exe [options]"
+	_, err = NewFinder(WithFinderRunner(r)).Version(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package vswhere
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionNumber is a four-part version number, such as the
+// InstallationVersion "17.9.34607.119".
+type VersionNumber struct {
+	Major, Minor, Build, Revision int
+}
+
+// ParseVersionNumber parses a dotted version of one to four numeric parts.
+// Missing parts are 0.
+func ParseVersionNumber(s string) (VersionNumber, error) {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	if len(parts) > 4 {
+		return VersionNumber{}, fmt.Errorf("invalid version %q: too many parts", s)
+	}
+
+	var (
+		v      VersionNumber
+		fields = []*int{&v.Major, &v.Minor, &v.Build, &v.Revision}
+	)
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return VersionNumber{}, fmt.Errorf("invalid version %q", s)
+		}
+		*fields[i] = int(n)
+	}
+	return v, nil
+}
+
+// ParsedVersion parses the InstallationVersion of the installation.
+func (i Installation) ParsedVersion() (VersionNumber, error) {
+	return ParseVersionNumber(i.InstallationVersion)
+}
+
+// Compare returns -1, 0, or 1 if v is older than, the same as, or newer than
+// other.
+func (v VersionNumber) Compare(other VersionNumber) int {
+	a := [...]int{v.Major, v.Minor, v.Build, v.Revision}
+	b := [...]int{other.Major, other.Minor, other.Build, other.Revision}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Less reports whether v is older than other.
+func (v VersionNumber) Less(other VersionNumber) bool {
+	return v.Compare(other) < 0
+}
+
+// String returns all four parts of the version, such as "17.9.0.0".
+func (v VersionNumber) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Build, v.Revision)
+}
@@ -0,0 +1,26 @@
+package vswhere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionNumber(t *testing.T) {
+	v, err := Installation{InstallationVersion: "17.9.34607.119"}.ParsedVersion()
+	require.NoError(t, err)
+	require.Equal(t, VersionNumber{17, 9, 34607, 119}, v)
+	require.Equal(t, "17.9.34607.119", v.String())
+
+	short, err := ParseVersionNumber("17.10")
+	require.NoError(t, err)
+	require.Equal(t, "17.10.0.0", short.String())
+	require.True(t, v.Less(short))
+	require.Equal(t, 1, short.Compare(v))
+	require.Equal(t, 0, v.Compare(v))
+
+	for _, s := range []string{"", "17.x", "1.2.3.4.5", "-1.0"} {
+		_, err := ParseVersionNumber(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,164 @@
+package vswhere
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// VersionRange is a range of installation versions, rendered by String in
+// the syntax vswhere expects, such as "[16.0,17.0)". The zero value accepts
+// any version.
+type VersionRange struct {
+	// Min is the lowest version in the range. Empty means no lower bound.
+	Min string
+
+	// Max is the highest version in the range. Empty means no upper bound.
+	Max string
+
+	// IncludeMin and IncludeMax include Min and Max in the range,
+	// respectively. They have no effect on an empty bound.
+	IncludeMin, IncludeMax bool
+}
+
+// AtLeast returns the range of versions >= min.
+func AtLeast(min string) VersionRange {
+	return VersionRange{Min: min, IncludeMin: true}
+}
+
+// Below returns the range of versions < max.
+func Below(max string) VersionRange {
+	return VersionRange{Max: max}
+}
+
+// Between returns the range of versions >= min and < max, such as all
+// versions of Visual Studio 2017 with Between("15.0", "16.0").
+func Between(min, max string) VersionRange {
+	return VersionRange{Min: min, Max: max, IncludeMin: true}
+}
+
+// Exactly returns the range holding only v. Versions missing trailing
+// components are padded with zeros, so Exactly("17.9") doesn't match
+// "17.9.34607.119"; use Between("17.9", "17.10") for that.
+func Exactly(v string) VersionRange {
+	return VersionRange{Min: v, Max: v, IncludeMin: true, IncludeMax: true}
+}
+
+// ParseVersionRange parses a version range such as "[16.0,17.0)" or
+// "(,18)". A bare version such as "17.0" is parsed as a minimum version,
+// like vswhere does. Returns an error wrapping ErrInvalidVersionRange if s
+// is malformed or the range can't hold any version.
+func ParseVersionRange(s string) (VersionRange, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return VersionRange{}, fmt.Errorf("%w: empty range", ErrInvalidVersionRange)
+	}
+	if !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "(") {
+		r := AtLeast(s)
+		return r, r.Validate()
+	}
+
+	if len(s) < 2 || (!strings.HasSuffix(s, "]") && !strings.HasSuffix(s, ")")) {
+		return VersionRange{}, fmt.Errorf("%w %q", ErrInvalidVersionRange, s)
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 2 {
+		return VersionRange{}, fmt.Errorf("%w %q", ErrInvalidVersionRange, s)
+	}
+	r := VersionRange{
+		Min:        strings.TrimSpace(parts[0]),
+		Max:        strings.TrimSpace(parts[1]),
+		IncludeMin: s[0] == '[',
+		IncludeMax: s[len(s)-1] == ']',
+	}
+	if r.Min == "" && r.Max == "" {
+		return VersionRange{}, fmt.Errorf("%w %q", ErrInvalidVersionRange, s)
+	}
+	return r, r.Validate()
+}
+
+// Validate returns an error wrapping ErrInvalidVersionRange if either bound
+// isn't a dotted numeric version or the range can't hold any version.
+func (r VersionRange) Validate() error {
+	for _, v := range []string{r.Min, r.Max} {
+		if v != "" && !validVersion(v) {
+			return fmt.Errorf("%w %q: %q is not a version", ErrInvalidVersionRange, r, v)
+		}
+	}
+	if r.Min != "" && r.Max != "" {
+		c := version.Compare(r.Min, r.Max)
+		if c > 0 || (c == 0 && !(r.IncludeMin && r.IncludeMax)) {
+			return fmt.Errorf("%w %q: no version can match", ErrInvalidVersionRange, r)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether v is within the range.
+func (r VersionRange) Contains(v string) bool {
+	if r.Min != "" {
+		c := version.Compare(v, r.Min)
+		if c < 0 || (c == 0 && !r.IncludeMin) {
+			return false
+		}
+	}
+	if r.Max != "" {
+		c := version.Compare(v, r.Max)
+		if c > 0 || (c == 0 && !r.IncludeMax) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the range of versions within both r and o. Validate
+// reports an error if no version is in both.
+func (r VersionRange) Intersect(o VersionRange) VersionRange {
+	res := r
+	if o.Min != "" {
+		c := version.Compare(o.Min, r.Min)
+		if r.Min == "" || c > 0 || (c == 0 && !o.IncludeMin) {
+			res.Min, res.IncludeMin = o.Min, o.IncludeMin
+		}
+	}
+	if o.Max != "" {
+		c := version.Compare(o.Max, r.Max)
+		if r.Max == "" || c < 0 || (c == 0 && !o.IncludeMax) {
+			res.Max, res.IncludeMax = o.Max, o.IncludeMax
+		}
+	}
+	return res
+}
+
+// String renders the range in the syntax used by vswhere. The zero value
+// renders as an empty string.
+func (r VersionRange) String() string {
+	if r.Min == "" && r.Max == "" {
+		return ""
+	}
+	open, close := "(", ")"
+	if r.IncludeMin && r.Min != "" {
+		open = "["
+	}
+	if r.IncludeMax && r.Max != "" {
+		close = "]"
+	}
+	return open + r.Min + "," + r.Max + close
+}
+
+// WithVersionRange is like WithVersion but takes a VersionRange.
+func WithVersionRange(r VersionRange) Option {
+	return WithVersion(r.String())
+}
+
+// validVersion reports whether v is a dotted numeric version.
+func validVersion(v string) bool {
+	for _, part := range strings.Split(v, ".") {
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package vswhere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionRange(t *testing.T) {
+	require.Equal(t, "[16.0,)", AtLeast("16.0").String())
+	require.Equal(t, "(,18)", Below("18").String())
+	require.Equal(t, "[15.0,16.0)", Between("15.0", "16.0").String())
+	require.Equal(t, "[17.9,17.9]", Exactly("17.9").String())
+	require.Equal(t, "", VersionRange{}.String())
+
+	r := Between("16.0", "17.0")
+	require.True(t, r.Contains("16.11.34601.136"))
+	require.True(t, r.Contains("16.0"))
+	require.False(t, r.Contains("17.0"))
+	require.False(t, r.Contains("15.9"))
+	require.True(t, VersionRange{}.Contains("17.0"))
+
+	_, err := BuildArgs(WithVersionRange(Between("16.0", "17.0")))
+	require.NoError(t, err)
+}
+
+func TestParseVersionRange(t *testing.T) {
+	for input, expect := range map[string]VersionRange{
+		"[16.0,17.0)": Between("16.0", "17.0"),
+		" 17 ":        AtLeast("17"),
+		"(,18)":       Below("18"),
+		"[17.9,17.9]": Exactly("17.9"),
+		"(16, 17]":    {Min: "16", Max: "17", IncludeMax: true},
+	} {
+		r, err := ParseVersionRange(input)
+		require.NoError(t, err, input)
+		require.Equal(t, expect, r, input)
+	}
+
+	for _, input := range []string{"", "[16.0", "[16,17,18]", "[,]", "[abc,)", "17.x", "[18,17)", "[17,17)"} {
+		_, err := ParseVersionRange(input)
+		require.True(t, errors.Is(err, ErrInvalidVersionRange), input)
+	}
+	require.Error(t, Between("17.0", "16.0").Validate())
+}
+
+func TestVersionRangeIntersect(t *testing.T) {
+	require.Equal(t, "[17.5,18.0)", Between("17.0", "18.0").Intersect(Between("17.5", "18.5")).String())
+	require.Equal(t, "(17.0,17.5]", Between("17.0", "18.0").Intersect(VersionRange{Min: "17.0", Max: "17.5", IncludeMax: true}).String())
+	require.Equal(t, "[16.0,17.0)", AtLeast("16.0").Intersect(Below("17.0")).String())
+	require.Error(t, Between("16.0", "17.0").Intersect(AtLeast("17.0")).Validate())
+}
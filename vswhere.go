@@ -1,75 +1,136 @@
-//+build windows
-
 // Package vswhere implements an interface to Microsoft's vswhere[1], a Visual
-// Studio Installation locator. vswhere must be installed, and is assumed to
-// be present in "%ProgramFiles(x86)%\Microsoft Visual Studio\Installer\vswhere.exe".
+// Studio Installation locator. vswhere is assumed to be present in
+// "%ProgramFiles(x86)%\Microsoft Visual Studio\Installer\vswhere.exe", unless
+// the VSWHERE_PATH environment variable names another location. If vswhere
+// isn't installed, the Setup Configuration API used by vswhere is queried
+// directly, though WithRequires and WithLegacy aren't supported and catalog
+// info and properties aren't reported.
 //
 //   [1]: https://github.com/microsoft/vswhere
 package vswhere
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/rfratto/vswhere/internal/platform"
+	"github.com/rfratto/vswhere/internal/version"
 )
 
+// ErrUnsupportedPlatform is returned on platforms other than Windows, where
+// Visual Studio can't be installed. The package compiles on every platform so
+// cross-platform tools don't need their own build constraints.
+var ErrUnsupportedPlatform = platform.ErrUnsupported
+
 // Installation is an individual installation of Visual Studio.
 type Installation struct {
-	InstanceID          string     `json:"instanceId"`
-	InstallDate         time.Time  `json:"installDate"`
-	InstallationName    string     `json:"installationName"`
-	InstallationPath    string     `json:"installationPath"`
-	InstallationVersion string     `json:"installationVersion"`
-	ProductID           string     `json:"productId"`
-	ProductPath         string     `json:"productPath"`
-	State               uint64     `json:"state"`
-	IsComplete          bool       `json:"isComplete"`
-	IsLaunchable        bool       `json:"isLaunchable"`
-	IsPrerelease        bool       `json:"isPrerelease"`
-	IsRebootRequired    bool       `json:"isRebootRequired"`
-	DisplayName         string     `json:"displayName"`
-	Description         string     `json:"description"`
-	ChannelID           string     `json:"channelId"`
-	ChannelURI          string     `json:"channelUri"`
-	EnginePath          string     `json:"enginePath"`
-	ReleaseNotes        string     `json:"releaseNotes"`
-	ThirdPartyNotices   string     `json:"thirdPartyNotices"`
-	UpdateDate          time.Time  `json:"updateDate"`
-	Catalog             Catalog    `json:"catalog"`
-	Properties          Properties `json:"properties"`
+	InstanceID          string     `json:"instanceId" yaml:"instanceId" toml:"instanceId"`
+	InstallDate         time.Time  `json:"installDate" yaml:"installDate" toml:"installDate"`
+	InstallationName    string     `json:"installationName" yaml:"installationName" toml:"installationName"`
+	InstallationPath    string     `json:"installationPath" yaml:"installationPath" toml:"installationPath"`
+	InstallationVersion string     `json:"installationVersion" yaml:"installationVersion" toml:"installationVersion"`
+	ProductID           string     `json:"productId" yaml:"productId" toml:"productId"`
+	ProductPath         string     `json:"productPath" yaml:"productPath" toml:"productPath"`
+	State               uint64     `json:"state" yaml:"state" toml:"state"`
+	IsComplete          bool       `json:"isComplete" yaml:"isComplete" toml:"isComplete"`
+	IsLaunchable        bool       `json:"isLaunchable" yaml:"isLaunchable" toml:"isLaunchable"`
+	IsPrerelease        bool       `json:"isPrerelease" yaml:"isPrerelease" toml:"isPrerelease"`
+	IsRebootRequired    bool       `json:"isRebootRequired" yaml:"isRebootRequired" toml:"isRebootRequired"`
+	DisplayName         string     `json:"displayName" yaml:"displayName" toml:"displayName"`
+	Description         string     `json:"description" yaml:"description" toml:"description"`
+	ChannelID           string     `json:"channelId" yaml:"channelId" toml:"channelId"`
+	ChannelURI          string     `json:"channelUri" yaml:"channelUri" toml:"channelUri"`
+	EnginePath          string     `json:"enginePath" yaml:"enginePath" toml:"enginePath"`
+	ReleaseNotes        string     `json:"releaseNotes" yaml:"releaseNotes" toml:"releaseNotes"`
+	ThirdPartyNotices   string     `json:"thirdPartyNotices" yaml:"thirdPartyNotices" toml:"thirdPartyNotices"`
+	UpdateDate          time.Time  `json:"updateDate" yaml:"updateDate" toml:"updateDate"`
+	Catalog             Catalog    `json:"catalog" yaml:"catalog" toml:"catalog"`
+	Properties          Properties `json:"properties" yaml:"properties" toml:"properties"`
+
+	// Packages are the packages installed with the instance. They are only
+	// reported with WithIncludePackages.
+	Packages []Package `json:"packages,omitempty" yaml:"packages,omitempty" toml:"packages,omitempty"`
+
+	// Errors describe packages which failed to install or were skipped. They
+	// are only reported with WithIncludeErrors.
+	Errors Errors `json:"errors" yaml:"errors" toml:"errors"`
 }
 
 // Catalog info from an installation.
 type Catalog struct {
-	BuildBranch                      string `json:"buildBranch"`
-	BuildVersion                     string `json:"buildVersion"`
-	ID                               string `json:"id"`
-	LocalBuild                       string `json:"localBuild"`
-	ManifestName                     string `json:"manifestName"`
-	ManifestType                     string `json:"manifestType"`
-	ProductDisplayVersion            string `json:"productDisplayVersion"`
-	ProductLine                      string `json:"productLine"`
-	ProductLineVersion               string `json:"productLineVersion"`
-	ProductMilestone                 string `json:"productMilestone"`
-	ProductMilestoneIsPrerelease     string `json:"productMilestoneIsPreRelease"`
-	ProductName                      string `json:"productName"`
-	ProductPatchVersion              string `json:"productPatchVersion"`
-	ProductPreReleaseMilestoneSuffix string `json:"productPreReleaseMilestoneSuffix"`
-	ProductSemanticVersion           string `json:"productSemanticVersion"`
-	RequiredEngineVersion            string `json:"requiredEngineVersion"`
+	BuildBranch                      string `json:"buildBranch" yaml:"buildBranch" toml:"buildBranch"`
+	BuildVersion                     string `json:"buildVersion" yaml:"buildVersion" toml:"buildVersion"`
+	ID                               string `json:"id" yaml:"id" toml:"id"`
+	LocalBuild                       string `json:"localBuild" yaml:"localBuild" toml:"localBuild"`
+	ManifestName                     string `json:"manifestName" yaml:"manifestName" toml:"manifestName"`
+	ManifestType                     string `json:"manifestType" yaml:"manifestType" toml:"manifestType"`
+	ProductDisplayVersion            string `json:"productDisplayVersion" yaml:"productDisplayVersion" toml:"productDisplayVersion"`
+	ProductLine                      string `json:"productLine" yaml:"productLine" toml:"productLine"`
+	ProductLineVersion               string `json:"productLineVersion" yaml:"productLineVersion" toml:"productLineVersion"`
+	ProductMilestone                 string `json:"productMilestone" yaml:"productMilestone" toml:"productMilestone"`
+	ProductMilestoneIsPrerelease     string `json:"productMilestoneIsPreRelease" yaml:"productMilestoneIsPreRelease" toml:"productMilestoneIsPreRelease"`
+	ProductName                      string `json:"productName" yaml:"productName" toml:"productName"`
+	ProductPatchVersion              string `json:"productPatchVersion" yaml:"productPatchVersion" toml:"productPatchVersion"`
+	ProductPreReleaseMilestoneSuffix string `json:"productPreReleaseMilestoneSuffix" yaml:"productPreReleaseMilestoneSuffix" toml:"productPreReleaseMilestoneSuffix"`
+	ProductSemanticVersion           string `json:"productSemanticVersion" yaml:"productSemanticVersion" toml:"productSemanticVersion"`
+	RequiredEngineVersion            string `json:"requiredEngineVersion" yaml:"requiredEngineVersion" toml:"requiredEngineVersion"`
 }
 
 // Properties from an installation.
 type Properties struct {
-	CampaignID          string `json:"campaignId"`
-	ChannelManifestID   string `json:"channelManifestId"`
-	Nickname            string `json:"nickname"`
-	SetupEngineFilePath string `json:"setupEngineFilePath"`
+	CampaignID          string `json:"campaignId" yaml:"campaignId" toml:"campaignId"`
+	ChannelManifestID   string `json:"channelManifestId" yaml:"channelManifestId" toml:"channelManifestId"`
+	Nickname            string `json:"nickname" yaml:"nickname" toml:"nickname"`
+	SetupEngineFilePath string `json:"setupEngineFilePath" yaml:"setupEngineFilePath" toml:"setupEngineFilePath"`
+}
+
+// Package is a single package installed with an installation, such as a
+// workload, component, or the MSI or VSIX which implements it.
+type Package struct {
+	ID       string `json:"id" yaml:"id" toml:"id"`
+	Version  string `json:"version" yaml:"version" toml:"version"`
+	Chip     string `json:"chip,omitempty" yaml:"chip,omitempty" toml:"chip,omitempty"`
+	Language string `json:"language,omitempty" yaml:"language,omitempty" toml:"language,omitempty"`
+	Type     string `json:"type" yaml:"type" toml:"type"`
+}
+
+// Errors are the errors recorded by the Visual Studio Installer for an
+// installation. An installation with failed packages is broken and needs to
+// be repaired.
+type Errors struct {
+	FailedPackages  []FailedPackage `json:"failedPackages,omitempty" yaml:"failedPackages,omitempty" toml:"failedPackages,omitempty"`
+	SkippedPackages []Package       `json:"skippedPackages,omitempty" yaml:"skippedPackages,omitempty" toml:"skippedPackages,omitempty"`
+}
+
+// FailedPackage is a package which failed to install.
+type FailedPackage struct {
+	ID          string `json:"id" yaml:"id" toml:"id"`
+	Version     string `json:"version" yaml:"version" toml:"version"`
+	Chip        string `json:"chip,omitempty" yaml:"chip,omitempty" toml:"chip,omitempty"`
+	Language    string `json:"language,omitempty" yaml:"language,omitempty" toml:"language,omitempty"`
+	Type        string `json:"type" yaml:"type" toml:"type"`
+	LogFilePath string `json:"logFilePath" yaml:"logFilePath" toml:"logFilePath"`
+	Description string `json:"description" yaml:"description" toml:"description"`
+	Signature   string `json:"signature" yaml:"signature" toml:"signature"`
+	Details     string `json:"details" yaml:"details" toml:"details"`
+}
+
+// installedPackages returns which of the package IDs, such as workloads or
+// components, are among the Packages of install. IDs are matched
+// case-insensitively, like vswhere does.
+func installedPackages(install Installation, ids []string) []string {
+	var res []string
+	for _, id := range ids {
+		for _, p := range install.Packages {
+			if strings.EqualFold(p.ID, id) {
+				res = append(res, id)
+				break
+			}
+		}
+	}
+	return res
 }
 
 type searchOptions struct {
@@ -77,10 +138,25 @@ type searchOptions struct {
 	prerelease  bool
 	products    []string
 	requires    []string
+	required    []string
 	requiresAny bool
 	version     string
 	latest      bool
 	legacy      bool
+	sort        bool
+	packages    bool
+	errors      bool
+	extraArgs   []string
+	installPath string
+	invariant   bool
+
+	executable string
+	runInfo    *RunInfo
+
+	// path finds the single installation containing a path, ignoring other
+	// selection options. It is set by Get, and by WithInstallPath when no
+	// other selection options are given.
+	path string
 }
 
 // Option customizes the query to vswhere.
@@ -109,6 +185,17 @@ func WithRequires(requires []string) Option {
 	return func(so *searchOptions) { so.requires = requires }
 }
 
+// withRequired adds component IDs to the requirements of a search without
+// replacing the ones given with WithRequires. Unlike WithRequires, every ID
+// must be installed even if WithRequiresAny is given. The IDs are checked
+// against the installed packages rather than only passed to vswhere.exe, so
+// backends which can't filter by component still answer the search.
+func withRequired(ids ...string) Option {
+	return func(so *searchOptions) {
+		so.required = append(append([]string(nil), so.required...), ids...)
+	}
+}
+
 // WithRequiresAny will return an instance if they match any of the requirements
 // provided with WithRequires.
 func WithRequiresAny(requiresAny bool) Option {
@@ -116,7 +203,8 @@ func WithRequiresAny(requiresAny bool) Option {
 }
 
 // WithVersion specifies a version range for instances to find. For example,
-// "[15.0,16.0)" will find all versions >=15.0 and <16.0.
+// "[15.0,16.0)" will find all versions >=15.0 and <16.0. Use
+// WithVersionRange to avoid writing ranges by hand.
 func WithVersion(versionRange string) Option {
 	return func(so *searchOptions) { so.version = versionRange }
 }
@@ -132,82 +220,320 @@ func WithLegacy(legacy bool) Option {
 	return func(so *searchOptions) { so.legacy = legacy }
 }
 
+// WithSort passes -sort to vswhere, ordering the last installed first among
+// installations of equal versions rather than by product ID, so installs[0] is
+// the installation WithLatest would select.
+func WithSort(sort bool) Option {
+	return func(so *searchOptions) { so.sort = sort }
+}
+
+// WithIncludePackages populates Installation.Packages with the packages of
+// each installation. Legacy installations found with WithLegacy have no
+// packages.
+func WithIncludePackages(include bool) Option {
+	return func(so *searchOptions) { so.packages = include }
+}
+
+// WithIncludeErrors populates Installation.Errors with the packages of each
+// installation which failed to install or were skipped, including the path of
+// the installer log for each failure. Combine with WithAll to find
+// installations which are incomplete. Only vswhere reports errors.
+func WithIncludeErrors(include bool) Option {
+	return func(so *searchOptions) { so.errors = include }
+}
+
+// WithExtraArgs passes args to vswhere before "-format json", allowing flags
+// which aren't modeled by this package to be used with Find. Flags which
+// change the output format (-format, -property, and -find) are rejected.
+// Backends other than vswhere can't apply extra arguments and are skipped.
+func WithExtraArgs(args ...string) Option {
+	return func(so *searchOptions) { so.extraArgs = append(so.extraArgs, args...) }
+}
+
+// WithInstallPath only finds the installation containing path, such as its
+// installation directory or a file beneath it. Unlike Get, it can be combined
+// with other options which select installations (e.g., WithRequires) to check
+// whether the installation at path meets them.
+func WithInstallPath(path string) Option {
+	return func(so *searchOptions) { so.installPath = path }
+}
+
+// WithInvariantNames replaces the localized DisplayName of each installation
+// with its English name built from the catalog (e.g., "Visual Studio Build
+// Tools 2022"), so logs and reports are consistent across machines with
+// different languages. Installations without catalog info keep their
+// DisplayName.
+func WithInvariantNames(invariant bool) Option {
+	return func(so *searchOptions) { so.invariant = invariant }
+}
+
+// WithExecutable uses the vswhere.exe at path for the query instead of the one
+// installed with the Visual Studio Installer. If WithExecutable isn't given,
+// the VSWHERE_PATH environment variable is used when set.
+func WithExecutable(path string) Option {
+	return func(so *searchOptions) { so.executable = path }
+}
+
+// RunInfo describes a single invocation of vswhere.
+type RunInfo struct {
+	// Path is the vswhere executable that was run. It is empty if vswhere
+	// wasn't installed and the Setup Configuration API was queried instead,
+	// or if vswhere was run by a custom Runner.
+	Path string
+
+	// Args are the arguments passed to vswhere.
+	Args []string
+
+	// Duration is how long the query took, including every backend queried.
+	Duration time.Duration
+
+	// ExitCode is the exit code of vswhere, or -1 if it failed to start or
+	// was killed. It is 0 if vswhere wasn't run.
+	ExitCode int
+
+	// Backend is the backend which answered the query, or -1 if none did.
+	// Installations added by BackendRegistry don't change it.
+	Backend Backend
+
+	// Unavailable holds the error which made each skipped backend
+	// unavailable, such as vswhere not being installed.
+	Unavailable map[Backend]error
+
+	// Sources holds the backend which found each installation, keyed by
+	// instance ID.
+	Sources map[string]Backend
+
+	// Excluded holds the installations removed by
+	// WithFinderExcludeIncomplete.
+	Excluded []Exclusion
+}
+
+// WithRunInfo records metadata about the vswhere invocation into info once the
+// query completes, even if it fails. info must not be nil.
+func WithRunInfo(info *RunInfo) Option {
+	return func(so *searchOptions) { so.runInfo = info }
+}
+
 // Find finds all installations. Options can be provided to customize the search
-// behavior.
+// behavior. Installations are ordered newest version first, then by product ID
+// and instance ID, regardless of the backend which found them.
 func Find(ctx context.Context, options ...Option) ([]Installation, error) {
+	return Default().Find(ctx, options...)
+}
+
+// BuildArgs returns the vswhere command line arguments that Find would use for
+// the given options, ending in "-format json". It allows running vswhere
+// through other means while keeping the same option handling. Returns an
+// error describing every problem if the options are invalid or
+// contradictory.
+func BuildArgs(options ...Option) ([]string, error) {
+	searchOpts := newSearchOptions(options)
+	return searchOpts.args()
+}
+
+// includePackages reports whether backends must report packages, either
+// because they were requested or to check the IDs given with withRequired.
+func (so *searchOptions) includePackages() bool {
+	return so.packages || len(so.required) > 0
+}
+
+func newSearchOptions(options []Option) searchOptions {
 	var searchOpts searchOptions
 	for _, o := range options {
 		o(&searchOpts)
 	}
+	if searchOpts.installPath != "" && !searchOpts.selects() {
+		// vswhere's -path can't be combined with other selection options,
+		// so it's only used when there are none; otherwise results are
+		// filtered by path after the query.
+		searchOpts.path = searchOpts.installPath
+	}
+	return searchOpts
+}
+
+// selects reports whether any options which select installations were given.
+func (so *searchOptions) selects() bool {
+	return so.all || so.prerelease || len(so.products) > 0 || len(so.requires) > 0 ||
+		len(so.required) > 0 || so.version != "" || so.latest || so.legacy
+}
+
+// containsPath reports whether the installation at installPath contains path.
+func containsPath(installPath, path string) bool {
+	normalize := func(p string) string {
+		return strings.TrimRight(strings.ToLower(strings.ReplaceAll(p, "/", `\`)), `\`)
+	}
+	installPath, path = normalize(installPath), normalize(path)
+	return installPath != "" && (path == installPath || strings.HasPrefix(path, installPath+`\`))
+}
+
+func (so *searchOptions) args() ([]string, error) {
+	if err := so.validate(); err != nil {
+		return nil, err
+	}
 
 	var args []string
-	if searchOpts.all {
+	if so.path != "" {
+		args = append(args, "-path", so.path)
+	} else {
+		args = append(args, so.selectionArgs()...)
+	}
+	if so.sort {
+		args = append(args, "-sort")
+	}
+	if so.includePackages() || so.errors {
+		args = append(args, "-include")
+		if so.includePackages() {
+			args = append(args, "packages")
+		}
+		if so.errors {
+			args = append(args, "errors")
+		}
+	}
+	args = append(args, so.extraArgs...)
+	args = append(args, "-format", "json")
+	return args, nil
+}
+
+// selectionArgs returns the arguments for options which select
+// installations.
+func (so *searchOptions) selectionArgs() []string {
+	var args []string
+	if so.all {
 		args = append(args, "-all")
 	}
-	if searchOpts.prerelease {
+	if so.prerelease {
 		args = append(args, "-prerelease")
 	}
-	if len(searchOpts.products) > 0 {
+	if len(so.products) > 0 {
 		args = append(args, "-products")
-		args = append(args, searchOpts.products...)
+		args = append(args, so.products...)
 	}
-	if len(searchOpts.requires) > 0 {
+	if len(so.requires) > 0 || len(so.required) > 0 {
 		args = append(args, "-requires")
-		args = append(args, searchOpts.requires...)
+		args = append(args, so.requires...)
+		args = append(args, so.required...)
 	}
-	if searchOpts.requiresAny {
+	if so.requiresAny {
 		args = append(args, "-requiresAny")
 	}
-	if searchOpts.version != "" {
-		args = append(args, "-version", searchOpts.version)
+	if so.version != "" {
+		args = append(args, "-version", so.version)
 	}
-	if searchOpts.latest {
+	if so.latest {
 		args = append(args, "-latest")
 	}
-	if searchOpts.legacy {
+	if so.legacy {
 		args = append(args, "-legacy")
 	}
-	args = append(args, "-format", "json")
-	return run(ctx, args)
+	return args
 }
 
-// Get returns an indivdiual installation within a path. Returns an error if the
-// installation wasn't found.
-func Get(ctx context.Context, path string) (Installation, error) {
-	installs, err := run(ctx, []string{"-path", path, "-format", "json"})
-	if err != nil {
-		return Installation{}, err
-	}
-	if len(installs) == 0 {
-		return Installation{}, fmt.Errorf("no install at path %s", path)
-	}
-	return installs[0], nil
-}
-
-func run(ctx context.Context, args []string) ([]Installation, error) {
-	vsWherePath := filepath.Join(
-		os.Getenv("ProgramFiles(x86)"),
-		"Microsoft Visual Studio",
-		"Installer",
-		"vswhere.exe",
-	)
-
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, vsWherePath, args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		if _, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("vswhere failed: %s", string(stderr.Bytes()))
-		}
-		return nil, fmt.Errorf("vswhere failed: %w", err)
+// defaultProducts are the products vswhere searches when none are given.
+var defaultProducts = []string{ProductCommunity, ProductProfessional, ProductEnterprise}
+
+// matches reports whether vswhere would return install for the search.
+func (so *searchOptions) matches(install Installation) (bool, error) {
+	if !so.all && (!install.IsComplete || !install.IsLaunchable) {
+		return false, nil
+	}
+	if !so.prerelease && install.IsPrerelease {
+		return false, nil
+	}
+
+	products := so.products
+	if len(products) == 0 {
+		products = defaultProducts
+	}
+	if !containsFold(products, "*") && !containsFold(products, install.ProductID) {
+		return false, nil
 	}
 
-	dec := json.NewDecoder(bytes.NewReader(stdout.Bytes()))
+	if so.version != "" {
+		return inVersionRange(install.InstallationVersion, so.version)
+	}
+	return true, nil
+}
 
-	var installs []Installation
-	if err := dec.Decode(&installs); err != nil {
-		return nil, fmt.Errorf("failed parsing output of vswhere: %w", err)
+// filterLatest returns only the newest installation, and the last installed
+// among equal versions, if WithLatest was given. Otherwise installs is
+// returned unchanged.
+func (so *searchOptions) filterLatest(installs []Installation) []Installation {
+	if !so.latest || len(installs) == 0 {
+		return installs
 	}
-	return installs, nil
+	sortNewest(installs)
+	return installs[:1]
+}
+
+// sortNewest sorts installs newest version first, and the last installed
+// first among equal versions, matching vswhere's -sort.
+func sortNewest(installs []Installation) {
+	sort.SliceStable(installs, func(i, j int) bool {
+		return installKeyOf(installs[i]).less(installKeyOf(installs[j]), true)
+	})
+}
+
+// installKey holds the fields used to merge, filter, and order installations.
+type installKey struct {
+	InstanceID          string    `json:"instanceId"`
+	InstallationPath    string    `json:"installationPath"`
+	InstallationVersion string    `json:"installationVersion"`
+	ProductID           string    `json:"productId"`
+	InstallDate         time.Time `json:"installDate"`
+}
+
+func installKeyOf(install Installation) installKey {
+	return installKey{
+		InstanceID:          install.InstanceID,
+		InstallationPath:    install.InstallationPath,
+		InstallationVersion: install.InstallationVersion,
+		ProductID:           install.ProductID,
+		InstallDate:         install.InstallDate,
+	}
+}
+
+// less orders installations newest version first, then by product ID and
+// instance ID. If byDate is true, the last installed is ordered first among
+// equal versions before comparing product IDs.
+func (k installKey) less(other installKey, byDate bool) bool {
+	if c := version.Compare(k.InstallationVersion, other.InstallationVersion); c != 0 {
+		return c > 0
+	}
+	if byDate && !k.InstallDate.Equal(other.InstallDate) {
+		return k.InstallDate.After(other.InstallDate)
+	}
+	if k.ProductID != other.ProductID {
+		return k.ProductID < other.ProductID
+	}
+	return k.InstanceID < other.InstanceID
+}
+
+// FindStream is like Find but delivers installations over a channel. The
+// installation channel is closed once all installations have been sent. At
+// most one error is sent to the error channel, which is closed after the
+// installation channel. If ctx is canceled before all installations are
+// received, ctx.Err() is sent to the error channel.
+func FindStream(ctx context.Context, options ...Option) (<-chan Installation, <-chan error) {
+	return Default().FindStream(ctx, options...)
+}
+
+// Get returns an indivdiual installation within a path. Returns an error if the
+// installation wasn't found. Options that select installations (such as
+// WithProducts) are ignored, but others (such as WithExecutable) apply.
+func Get(ctx context.Context, path string, options ...Option) (Installation, error) {
+	return Default().Get(ctx, path, options...)
+}
+
+// FindWithTimeout calls Find with a context that is canceled after d.
+func FindWithTimeout(d time.Duration, options ...Option) ([]Installation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return Find(ctx, options...)
+}
+
+// GetWithTimeout calls Get with a context that is canceled after d.
+func GetWithTimeout(d time.Duration, path string, options ...Option) (Installation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return Get(ctx, path, options...)
 }
@@ -0,0 +1,16 @@
+//+build !windows
+
+package vswhere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnsupported(t *testing.T) {
+	_, err := Find(context.Background())
+	require.True(t, errors.Is(err, ErrUnsupportedPlatform))
+}
@@ -1,35 +1,79 @@
-//+build windows
-
 package vswhere
 
 import (
-	"context"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
-func TestFind(t *testing.T) {
-	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	installs, err := Find(timeout, WithAll(true))
+func TestBuildArgs(t *testing.T) {
+	args, err := BuildArgs(
+		WithAll(true),
+		WithProducts([]string{"*"}),
+		WithRequires([]string{"Microsoft.VisualStudio.Component.VC.Tools.x86.x64"}),
+		WithVersion("[16.0,17.0)"),
+		WithLatest(true),
+		WithSort(true),
+		WithIncludePackages(true),
+		WithIncludeErrors(true),
+	)
 	require.NoError(t, err)
-	require.True(t, len(installs) > 0)
-}
+	require.Equal(t, []string{
+		"-all",
+		"-products", "*",
+		"-requires", "Microsoft.VisualStudio.Component.VC.Tools.x86.x64",
+		"-version", "[16.0,17.0)",
+		"-latest",
+		"-sort",
+		"-include", "packages", "errors",
+		"-format", "json",
+	}, args)
 
-func TestGet(t *testing.T) {
-	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+	_, err = BuildArgs(WithLegacy(true), WithProducts([]string{"*"}))
+	require.Error(t, err)
 
-	installs, err := Find(timeout, WithAll(true))
+	args, err = BuildArgs(WithLatest(true), WithExtraArgs("-nocolor", "-include", "future"))
 	require.NoError(t, err)
-	require.True(t, len(installs) > 0)
+	require.Equal(t, []string{"-latest", "-nocolor", "-include", "future", "-format", "json"}, args)
+
+	for _, flag := range []string{"-format", "/Format", "-property", "-find"} {
+		_, err = BuildArgs(WithExtraArgs(flag, "value"))
+		require.Error(t, err, flag)
+	}
+}
+
+func TestSearchOptionsMatches(t *testing.T) {
+	community := Installation{
+		InstallationVersion: "17.8.34322.80",
+		ProductID:           "Microsoft.VisualStudio.Product.Community",
+		IsComplete:          true,
+		IsLaunchable:        true,
+	}
+	buildTools := community
+	buildTools.ProductID = "Microsoft.VisualStudio.Product.BuildTools"
+	incomplete := community
+	incomplete.IsComplete = false
+	prerelease := community
+	prerelease.IsPrerelease = true
 
-	for _, install := range installs {
-		i, err := Get(timeout, install.InstallationPath)
+	for _, tc := range []struct {
+		install Installation
+		options []Option
+		expect  bool
+	}{
+		{community, nil, true},
+		{buildTools, nil, false},
+		{buildTools, []Option{WithProducts([]string{"*"})}, true},
+		{incomplete, nil, false},
+		{incomplete, []Option{WithAll(true)}, true},
+		{prerelease, nil, false},
+		{prerelease, []Option{WithPrerelease(true)}, true},
+		{community, []Option{WithVersion("[16.0,17.0)")}, false},
+		{community, []Option{WithVersion("[17.0,18.0)")}, true},
+	} {
+		so := newSearchOptions(tc.options)
+		ok, err := so.matches(tc.install)
 		require.NoError(t, err)
-		require.Equal(t, install, i)
+		require.Equal(t, tc.expect, ok, "%+v %+v", tc.install, so)
 	}
 }
@@ -0,0 +1,76 @@
+//+build windows
+
+package vswhere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	installs, err := Find(timeout, WithAll(true))
+	require.NoError(t, err)
+	require.True(t, len(installs) > 0)
+}
+
+func TestGet(t *testing.T) {
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	installs, err := Find(timeout, WithAll(true))
+	require.NoError(t, err)
+	require.True(t, len(installs) > 0)
+
+	for _, install := range installs {
+		i, err := Get(timeout, install.InstallationPath)
+		require.NoError(t, err)
+		require.Equal(t, install, i)
+	}
+}
+
+func TestFindStream(t *testing.T) {
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	installCh, errCh := FindStream(timeout, WithAll(true))
+
+	var installs []Installation
+	for install := range installCh {
+		installs = append(installs, install)
+	}
+	require.NoError(t, <-errCh)
+	require.True(t, len(installs) > 0)
+}
+
+func TestWithRunInfo(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "vswhere.exe")
+
+	var info RunInfo
+	_, err := Find(context.Background(), WithExecutable(exe), WithLatest(true), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, exe, info.Path)
+	require.Equal(t, []string{"-latest", "-format", "json"}, info.Args)
+	require.Equal(t, -1, info.ExitCode)
+}
+
+func TestVSWherePathEnv(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "vswhere.exe")
+	setenv(t, "VSWHERE_PATH", exe)
+
+	var info RunInfo
+	_, err := Find(context.Background(), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, exe, info.Path)
+
+	override := filepath.Join(t.TempDir(), "vswhere.exe")
+	_, err = Find(context.Background(), WithExecutable(override), WithRunInfo(&info))
+	require.Error(t, err)
+	require.Equal(t, override, info.Path)
+}
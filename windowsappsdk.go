@@ -0,0 +1,77 @@
+package vswhere
+
+import "context"
+
+// Component IDs of the Windows App SDK (WinUI 3) tooling.
+const (
+	WindowsAppSDKCSharpComponent = "Microsoft.VisualStudio.ComponentGroup.WindowsAppSDK.Cs"
+	WindowsAppSDKCppComponent    = "Microsoft.VisualStudio.ComponentGroup.WindowsAppSDK.Cpp"
+	MSIXPackagingComponent       = "Microsoft.VisualStudio.ComponentGroup.MSIX.Packaging"
+)
+
+var windowsAppSDKComponents = []string{
+	WindowsAppSDKCSharpComponent,
+	WindowsAppSDKCppComponent,
+	MSIXPackagingComponent,
+}
+
+// WindowsAppSDKSupport is an installation with tooling for Windows App SDK
+// apps.
+type WindowsAppSDKSupport struct {
+	Installation Installation
+
+	// Components are the IDs of the Windows App SDK components (see the
+	// WindowsAppSDK*Component and MSIXPackagingComponent constants)
+	// installed in the installation.
+	Components []string
+}
+
+// HasComponent returns true if the component with the given ID is installed.
+func (s WindowsAppSDKSupport) HasComponent(id string) bool {
+	for _, c := range s.Components {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CSharp returns true if the C# WinUI project templates and tools are
+// installed.
+func (s WindowsAppSDKSupport) CSharp() bool { return s.HasComponent(WindowsAppSDKCSharpComponent) }
+
+// Cpp returns true if the C++ WinUI project templates and tools are
+// installed.
+func (s WindowsAppSDKSupport) Cpp() bool { return s.HasComponent(WindowsAppSDKCppComponent) }
+
+// FindWindowsAppSDK finds installations with the Windows App SDK templates
+// and tools for C# or C++, and reports which Windows App SDK components they
+// include, reading the packages of each installation in a single query.
+// Options can be provided to further customize the search.
+//
+// The Windows App SDK runtime itself is referenced by projects as a NuGet
+// package and isn't part of an installation.
+func FindWindowsAppSDK(ctx context.Context, options ...Option) ([]WindowsAppSDKSupport, error) {
+	return Default().FindWindowsAppSDK(ctx, options...)
+}
+
+// FindWindowsAppSDK finds installations with Windows App SDK tooling using
+// the Finder.
+func (f *Finder) FindWindowsAppSDK(ctx context.Context, options ...Option) ([]WindowsAppSDKSupport, error) {
+	installs, err := f.Find(ctx, append(options, WithIncludePackages(true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []WindowsAppSDKSupport
+	for _, install := range installs {
+		s := WindowsAppSDKSupport{
+			Installation: install,
+			Components:   installedPackages(install, windowsAppSDKComponents),
+		}
+		if s.CSharp() || s.Cpp() {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package vswhere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWindowsAppSDK(t *testing.T) {
+	r := &fakeRunner{stdout: `[
+  {
+    "instanceId": "cs",
+    "packages": [
+      {"id": "Microsoft.VisualStudio.ComponentGroup.WindowsAppSDK.Cs", "type": "Group"},
+      {"id": "Microsoft.VisualStudio.ComponentGroup.MSIX.Packaging", "type": "Group"}
+    ]
+  },
+  {
+    "instanceId": "cpp",
+    "packages": [{"id": "Microsoft.VisualStudio.ComponentGroup.WindowsAppSDK.Cpp", "type": "Group"}]
+  },
+  {
+    "instanceId": "msix-only",
+    "packages": [{"id": "Microsoft.VisualStudio.ComponentGroup.MSIX.Packaging", "type": "Group"}]
+  }
+]`}
+
+	res, err := NewFinder(WithFinderRunner(r)).FindWindowsAppSDK(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	// Installations are ordered by instance ID when their versions match.
+	require.Equal(t, "cpp", res[0].Installation.InstanceID)
+	require.True(t, res[0].Cpp())
+	require.False(t, res[0].HasComponent(MSIXPackagingComponent))
+
+	require.Equal(t, "cs", res[1].Installation.InstanceID)
+	require.True(t, res[1].CSharp())
+	require.False(t, res[1].Cpp())
+	require.True(t, res[1].HasComponent(MSIXPackagingComponent))
+}
@@ -0,0 +1,40 @@
+package winsdk
+
+import (
+	"context"
+	"os/exec"
+)
+
+// CppWinRT returns the path to the cppwinrt.exe shipped with an SDK version
+// (10.0.17134 and later). An empty version uses the newest installed SDK.
+func CppWinRT(version, arch string) (string, error) {
+	return ResolveTool(version, arch, "cppwinrt")
+}
+
+// CppWinRTCommand returns a command that generates the C++/WinRT projection
+// headers for the metadata of an SDK version into the output directory. An
+// empty version uses the newest installed SDK. extraArgs are appended to the
+// command line.
+func CppWinRTCommand(ctx context.Context, version, arch, output string, extraArgs ...string) (*exec.Cmd, error) {
+	return cppWinRTCommand(ctx, Root(), version, arch, output, extraArgs...)
+}
+
+func cppWinRTCommand(ctx context.Context, root, version, arch, output string, extraArgs ...string) (*exec.Cmd, error) {
+	if version == "" {
+		vers, err := versions(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(vers) > 0 {
+			version = vers[0]
+		}
+	}
+
+	path, err := resolveTool(root, version, arch, "cppwinrt")
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{"-input", version, "-output", output}, extraArgs...)
+	return exec.CommandContext(ctx, path, args...), nil
+}
@@ -0,0 +1,32 @@
+package winsdk
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCppWinRTCommand(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "Include", "10.0.17763.0", "um", "windows.h")
+	mkfile(t, root, "Include", "10.0.22621.0", "um", "windows.h")
+	mkfile(t, root, "bin", "10.0.17763.0", "x64", "cppwinrt.exe")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "cppwinrt.exe")
+
+	cmd, err := cppWinRTCommand(context.Background(), root, "", "x64", "gen", "-verbose")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.22621.0", "x64", "cppwinrt.exe"), cmd.Path)
+	require.Equal(t, []string{cmd.Path, "-input", "10.0.22621.0", "-output", "gen", "-verbose"}, cmd.Args)
+
+	cmd, err = cppWinRTCommand(context.Background(), root, "10.0.17763.0", "x64", "gen")
+	require.NoError(t, err)
+	require.Equal(t, []string{cmd.Path, "-input", "10.0.17763.0", "-output", "gen"}, cmd.Args)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.17763.0", "x64", "cppwinrt.exe"), cmd.Path)
+
+	// cppwinrt ships with SDKs since 10.0.17134.
+	mkfile(t, root, "bin", "10.0.16299.0", "x64", "rc.exe")
+	_, err = cppWinRTCommand(context.Background(), root, "10.0.16299.0", "x64", "gen")
+	require.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package winsdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MIDL is the MIDL compiler of an SDK version along with the environment it
+// needs to run outside of a developer command prompt.
+type MIDL struct {
+	// Path to midl.exe.
+	Path string
+
+	// IncludePaths are the SDK include directories searched for imported IDL
+	// files (um, shared, and winrt).
+	IncludePaths []string
+}
+
+// FindMIDL locates midl.exe for an SDK version and architecture. An empty
+// version uses the newest installed SDK.
+func FindMIDL(version, arch string) (MIDL, error) {
+	return findMIDL(Root(), version, arch)
+}
+
+func findMIDL(root, version, arch string) (MIDL, error) {
+	if version == "" {
+		vers, err := versions(root)
+		if err != nil {
+			return MIDL{}, err
+		}
+		if len(vers) == 0 {
+			return MIDL{}, fmt.Errorf("no Windows SDK installed")
+		}
+		version = vers[0]
+	}
+
+	path, err := resolveTool(root, version, arch, "midl")
+	if err != nil {
+		return MIDL{}, err
+	}
+
+	m := MIDL{Path: path}
+	for _, name := range []string{"um", "shared", "winrt"} {
+		dir := filepath.Join(root, "Include", version, name)
+		if isDir(dir) {
+			m.IncludePaths = append(m.IncludePaths, dir)
+		}
+	}
+	return m, nil
+}
+
+// Command returns a command running midl.exe with args. MIDL preprocesses IDL
+// files with the C++ compiler, so clPath must be the path to a cl.exe (e.g.,
+// from vswhere.Toolset.BinPath). The command's environment includes the SDK
+// include paths and the directory of cl.exe.
+func (m MIDL) Command(ctx context.Context, clPath string, args ...string) *exec.Cmd {
+	args = append([]string{"/cpp_cmd", clPath}, args...)
+	cmd := exec.CommandContext(ctx, m.Path, args...)
+
+	include := strings.Join(m.IncludePaths, string(os.PathListSeparator))
+	if v := os.Getenv("INCLUDE"); v != "" {
+		include += string(os.PathListSeparator) + v
+	}
+	path := filepath.Dir(clPath)
+	if v := os.Getenv("PATH"); v != "" {
+		path += string(os.PathListSeparator) + v
+	}
+	cmd.Env = append(os.Environ(), "INCLUDE="+include, "PATH="+path)
+	return cmd
+}
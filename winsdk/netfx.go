@@ -0,0 +1,47 @@
+package winsdk
+
+import "path/filepath"
+
+// NetFXSDK is an installed .NET Framework SDK.
+type NetFXSDK struct {
+	// Version of the SDK (e.g., "4.8").
+	Version string
+
+	// Path is the root of the SDK (e.g., "Windows Kits\NETFXSDK\4.8").
+	Path string
+
+	// ToolsPaths holds the directories containing SDK tools (e.g., al.exe,
+	// resgen.exe), keyed by architecture. Only architectures with installed
+	// tools are present.
+	ToolsPaths map[string]string
+
+	// TargetingPack is the directory holding reference assemblies for the
+	// framework version. Empty if the targeting pack isn't installed.
+	TargetingPack string
+}
+
+// IncludePath returns the directory of the SDK's native headers.
+func (s NetFXSDK) IncludePath() string {
+	return filepath.Join(s.Path, "Include", "um")
+}
+
+// LibPath returns the directory of the SDK's native libraries for an
+// architecture.
+func (s NetFXSDK) LibPath(arch string) string {
+	return filepath.Join(s.Path, "Lib", "um", arch)
+}
+
+// targetingPack returns the directory of the reference assemblies for a
+// framework version within programFiles, or an empty string if the targeting
+// pack isn't installed.
+func targetingPack(programFiles, version string) string {
+	pack := filepath.Join(
+		programFiles,
+		"Reference Assemblies", "Microsoft", "Framework", ".NETFramework",
+		"v"+version,
+	)
+	if !isDir(pack) {
+		return ""
+	}
+	return pack
+}
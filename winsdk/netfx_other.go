@@ -0,0 +1,9 @@
+//+build !windows
+
+package winsdk
+
+// NetFXSDKs returns the .NET Framework SDKs registered on the machine, newest
+// first.
+func NetFXSDKs() ([]NetFXSDK, error) {
+	return nil, ErrUnsupportedPlatform
+}
@@ -0,0 +1,25 @@
+package winsdk
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetFXSDKPaths(t *testing.T) {
+	sdk := NetFXSDK{Version: "4.8", Path: filepath.Join("Windows Kits", "NETFXSDK", "4.8")}
+	require.Equal(t, filepath.Join(sdk.Path, "Include", "um"), sdk.IncludePath())
+	require.Equal(t, filepath.Join(sdk.Path, "Lib", "um", "x64"), sdk.LibPath("x64"))
+}
+
+func TestTargetingPack(t *testing.T) {
+	programFiles := t.TempDir()
+	mkfile(t, programFiles, "Reference Assemblies", "Microsoft", "Framework", ".NETFramework", "v4.8", "mscorlib.dll")
+
+	require.Equal(t,
+		filepath.Join(programFiles, "Reference Assemblies", "Microsoft", "Framework", ".NETFramework", "v4.8"),
+		targetingPack(programFiles, "4.8"),
+	)
+	require.Empty(t, targetingPack(programFiles, "4.7.2"))
+}
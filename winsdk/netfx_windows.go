@@ -0,0 +1,86 @@
+//+build windows
+
+package winsdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rfratto/vswhere/internal/version"
+	"golang.org/x/sys/windows/registry"
+)
+
+// netfxKey is the registry key listing installed .NET Framework SDKs. It is
+// read from the 32-bit registry view.
+const netfxKey = `SOFTWARE\Microsoft\Microsoft SDKs\NETFXSDK`
+
+// NetFXSDKs returns the .NET Framework SDKs registered on the machine, newest
+// first.
+func NetFXSDKs() ([]NetFXSDK, error) {
+	root, err := registry.OpenKey(registry.LOCAL_MACHINE, netfxKey, registry.READ|registry.WOW64_32KEY)
+	if err == registry.ErrNotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open NETFXSDK registry key: %w", err)
+	}
+	defer root.Close()
+
+	versions, err := root.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list .NET Framework SDKs: %w", err)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version.Compare(versions[i], versions[j]) > 0
+	})
+
+	var sdks []NetFXSDK
+	for _, v := range versions {
+		sdk, ok := readNetFXSDK(root, v)
+		if ok {
+			sdks = append(sdks, sdk)
+		}
+	}
+	return sdks, nil
+}
+
+// netfxToolsKeys maps the registry subkeys holding tool paths to their
+// architecture.
+var netfxToolsKeys = map[string]string{
+	"WinSDK-NetFx40Tools":       "x86",
+	"WinSDK-NetFx40Tools-x64":   "x64",
+	"WinSDK-NetFx40Tools-arm64": "arm64",
+}
+
+func readNetFXSDK(root registry.Key, version string) (NetFXSDK, bool) {
+	k, err := registry.OpenKey(root, version, registry.READ)
+	if err != nil {
+		return NetFXSDK{}, false
+	}
+	defer k.Close()
+
+	path, _, err := k.GetStringValue("KitsInstallationFolder")
+	if err != nil {
+		return NetFXSDK{}, false
+	}
+
+	sdk := NetFXSDK{
+		Version:    version,
+		Path:       filepath.Clean(path),
+		ToolsPaths: make(map[string]string),
+	}
+	for name, arch := range netfxToolsKeys {
+		tk, err := registry.OpenKey(k, name, registry.READ)
+		if err != nil {
+			continue
+		}
+		if dir, _, err := tk.GetStringValue("InstallationFolder"); err == nil {
+			sdk.ToolsPaths[arch] = filepath.Clean(dir)
+		}
+		tk.Close()
+	}
+
+	sdk.TargetingPack = targetingPack(os.Getenv("ProgramFiles(x86)"), version)
+	return sdk, true
+}
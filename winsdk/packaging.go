@@ -0,0 +1,35 @@
+package winsdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MakeAppx returns the path to makeappx.exe, which creates MSIX and AppX
+// packages, for an SDK version and architecture. An empty version uses the
+// newest installed SDK.
+func MakeAppx(version, arch string) (string, error) {
+	return ResolveTool(version, arch, "makeappx")
+}
+
+// MakePri returns the path to makepri.exe, which creates package resource
+// index files, for an SDK version and architecture. An empty version uses the
+// newest installed SDK.
+func MakePri(version, arch string) (string, error) {
+	return ResolveTool(version, arch, "makepri")
+}
+
+// AppCert returns the path to appcert.exe from the Windows App Certification
+// Kit. The kit is not versioned and is shared by all installed SDKs.
+func AppCert() (string, error) {
+	return appCert(Root())
+}
+
+func appCert(root string) (string, error) {
+	path := filepath.Join(root, "App Certification Kit", "appcert.exe")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("Windows App Certification Kit not found: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,33 @@
+package winsdk
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackagingTools(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "Include", "10.0.22621.0", "um", "windows.h")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "makeappx.exe")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "makepri.exe")
+
+	path, err := resolveTool(root, "", "x64", "makeappx")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.22621.0", "x64", "makeappx.exe"), path)
+	path, err = resolveTool(root, "", "x64", "makepri")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.22621.0", "x64", "makepri.exe"), path)
+}
+
+func TestAppCert(t *testing.T) {
+	root := t.TempDir()
+	_, err := appCert(root)
+	require.Error(t, err)
+
+	mkfile(t, root, "App Certification Kit", "appcert.exe")
+	path, err := appCert(root)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "App Certification Kit", "appcert.exe"), path)
+}
@@ -0,0 +1,41 @@
+package winsdk
+
+import "github.com/rfratto/vswhere/internal/filever"
+
+// ShaderCompiler is an HLSL compiler from the SDK.
+type ShaderCompiler struct {
+	// Path to the compiler.
+	Path string
+
+	// Version is the file version of the compiler.
+	Version string
+}
+
+// FXC locates fxc.exe, the legacy HLSL compiler for shader models up to 5.1,
+// for an SDK version and architecture. An empty version uses the newest
+// installed SDK.
+func FXC(version, arch string) (ShaderCompiler, error) {
+	return shaderCompiler(Root(), version, arch, "fxc")
+}
+
+// DXC locates dxc.exe, the DirectX Shader Compiler for shader model 6 and
+// later, for an SDK version and architecture. dxc ships with SDKs since
+// 10.0.17763. An empty version uses the newest installed SDK.
+func DXC(version, arch string) (ShaderCompiler, error) {
+	return shaderCompiler(Root(), version, arch, "dxc")
+}
+
+// fileVersion reads the version of a compiler. It is replaced in tests.
+var fileVersion = filever.Get
+
+func shaderCompiler(root, version, arch, name string) (ShaderCompiler, error) {
+	path, err := resolveTool(root, version, arch, name)
+	if err != nil {
+		return ShaderCompiler{}, err
+	}
+	ver, err := fileVersion(path)
+	if err != nil {
+		return ShaderCompiler{}, err
+	}
+	return ShaderCompiler{Path: path, Version: ver}, nil
+}
@@ -0,0 +1,31 @@
+package winsdk
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShaderCompiler(t *testing.T) {
+	prev := fileVersion
+	fileVersion = func(path string) (string, error) { return "10.0.22621.755", nil }
+	t.Cleanup(func() { fileVersion = prev })
+
+	root := t.TempDir()
+	mkfile(t, root, "Include", "10.0.22621.0", "um", "windows.h")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "fxc.exe")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "dxc.exe")
+
+	for _, name := range []string{"fxc", "dxc"} {
+		c, err := shaderCompiler(root, "", "x64", name)
+		require.NoError(t, err)
+		require.Equal(t, ShaderCompiler{
+			Path:    filepath.Join(root, "bin", "10.0.22621.0", "x64", name+".exe"),
+			Version: "10.0.22621.755",
+		}, c)
+	}
+
+	_, err := shaderCompiler(root, "", "arm64", "dxc")
+	require.Error(t, err)
+}
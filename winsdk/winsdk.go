@@ -0,0 +1,114 @@
+// Package winsdk locates tools from the Windows 10/11 SDK. The SDK is assumed
+// to be installed in "%ProgramFiles(x86)%\Windows Kits\10".
+//
+// Architectures are given as the SDK's directory names: "x86", "x64", "arm",
+// or "arm64".
+package winsdk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rfratto/vswhere/internal/platform"
+	"github.com/rfratto/vswhere/internal/version"
+)
+
+// ErrUnsupportedPlatform is returned on platforms other than Windows by
+// functions that need Windows APIs.
+var ErrUnsupportedPlatform = platform.ErrUnsupported
+
+// Root returns the installation root of the Windows SDK.
+func Root() string {
+	return filepath.Join(os.Getenv("ProgramFiles(x86)"), "Windows Kits", "10")
+}
+
+// Versions returns the installed SDK versions, newest first.
+func Versions() ([]string, error) {
+	return versions(Root())
+}
+
+func versions(root string) ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(root, "Include"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Windows SDK versions: %w", err)
+	}
+
+	var vers []string
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), "10.") {
+			vers = append(vers, e.Name())
+		}
+	}
+	sort.Slice(vers, func(i, j int) bool {
+		return version.Compare(vers[i], vers[j]) > 0
+	})
+	return vers, nil
+}
+
+// BinPath returns the directory holding SDK tools for a version and
+// architecture. SDKs since 10.0.15063 install tools in "bin\<version>\<arch>";
+// older SDKs use the unversioned "bin\<arch>". An empty version uses the
+// newest installed SDK.
+func BinPath(version, arch string) (string, error) {
+	return binPath(Root(), version, arch)
+}
+
+// versionedBinSince is the first SDK version with versioned bin directories.
+const versionedBinSince = "10.0.15063"
+
+func binPath(root, ver, arch string) (string, error) {
+	if ver == "" {
+		vers, err := versions(root)
+		if err != nil {
+			return "", err
+		}
+		if len(vers) > 0 {
+			ver = vers[0]
+		}
+	}
+
+	if ver == "" || version.Compare(ver, versionedBinSince) < 0 {
+		dir := filepath.Join(root, "bin", arch)
+		if isDir(dir) {
+			return dir, nil
+		}
+	} else {
+		dir := filepath.Join(root, "bin", ver, arch)
+		if isDir(dir) {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no Windows SDK bin directory for version %q and arch %q", ver, arch)
+}
+
+// ResolveTool returns the path to an SDK tool (e.g., "rc", "mt", or
+// "signtool") for a version and architecture. The ".exe" extension is added
+// to name when it has no extension.
+func ResolveTool(version, arch, name string) (string, error) {
+	return resolveTool(Root(), version, arch, name)
+}
+
+func resolveTool(root, version, arch, name string) (string, error) {
+	if filepath.Ext(name) == "" {
+		name += ".exe"
+	}
+
+	dir, err := binPath(root, version, arch)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("%s not found in %s", name, dir)
+	}
+	return path, nil
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
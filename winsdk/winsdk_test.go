@@ -0,0 +1,61 @@
+package winsdk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTool(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "Include", "10.0.17763.0", "um", "windows.h")
+	mkfile(t, root, "Include", "10.0.22621.0", "um", "windows.h")
+	mkfile(t, root, "bin", "10.0.17763.0", "x64", "rc.exe")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "rc.exe")
+	mkfile(t, root, "bin", "x86", "mt.exe")
+
+	path, err := resolveTool(root, "", "x64", "rc")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.22621.0", "x64", "rc.exe"), path)
+
+	path, err = resolveTool(root, "10.0.17763.0", "x64", "rc.exe")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.17763.0", "x64", "rc.exe"), path)
+
+	// SDKs before 10.0.15063 use the unversioned layout.
+	path, err = resolveTool(root, "10.0.14393.0", "x86", "mt")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "x86", "mt.exe"), path)
+
+	// Newer SDKs never fall back to another SDK's tools.
+	_, err = resolveTool(root, "10.0.17763.0", "x86", "mt")
+	require.Error(t, err)
+
+	_, err = resolveTool(root, "", "x64", "signtool")
+	require.Error(t, err)
+}
+
+func TestFindMIDL(t *testing.T) {
+	root := t.TempDir()
+	mkfile(t, root, "Include", "10.0.22621.0", "um", "oaidl.idl")
+	mkfile(t, root, "Include", "10.0.22621.0", "shared", "wtypes.idl")
+	mkfile(t, root, "bin", "10.0.22621.0", "x64", "midl.exe")
+
+	m, err := findMIDL(root, "", "x64")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "bin", "10.0.22621.0", "x64", "midl.exe"), m.Path)
+	require.Equal(t, []string{
+		filepath.Join(root, "Include", "10.0.22621.0", "um"),
+		filepath.Join(root, "Include", "10.0.22621.0", "shared"),
+	}, m.IncludePaths)
+}
+
+func mkfile(t *testing.T, elem ...string) {
+	t.Helper()
+	path := filepath.Join(elem...)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+}